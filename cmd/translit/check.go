@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/eevan78/translit/internal/dictionary"
+	"github.com/eevan78/translit/internal/exit"
+	"github.com/eevan78/translit/internal/language"
+	"github.com/eevan78/translit/internal/messages"
+	"github.com/eevan78/translit/internal/terminal"
+	"github.com/spf13/pflag"
+)
+
+// Name of the standard input in the list of the words to change
+const standardInput = "-"
+
+// Writes every word of the plain text which the transliteration would change. Returns exit.Untransliterated
+// when there is such a word, so that the command can guard the texts which have to stay in one script.
+func runCheck(flags *pflag.FlagSet) (int, error) {
+	direction, err := checkDirection()
+	if err != nil {
+		return 0, err
+	}
+	if err := terminal.CheckScheme(); err != nil {
+		return 0, err
+	}
+
+	paths := flags.Args()
+	if *dictionary.InputPathPtr != "" {
+		paths = append([]string{*dictionary.InputPathPtr}, paths...)
+	}
+	if len(paths) == 0 {
+		paths = []string{standardInput}
+	}
+
+	words, failed := 0, false
+	for _, path := range paths {
+		changed, err := checkFile(path, direction)
+		words += changed
+		if err != nil {
+			exit.PrintError(err, path)
+			failed = true
+		}
+	}
+
+	if words > 0 {
+		messages.Fprintf(os.Stderr, messages.WordsToChange, words)
+	}
+	switch {
+	case failed:
+		return exit.Failure, nil
+	case words > 0:
+		return exit.Untransliterated, nil
+	}
+	return exit.Success, nil
+}
+
+// Returns the only direction given with the flags.
+func checkDirection() (language.Direction, error) {
+	var names []string
+	for _, direction := range []struct {
+		name string
+		set  bool
+	}{{"l2c", *dictionary.L2cPtr}, {"c2l", *dictionary.C2lPtr}, {"c2a", *dictionary.C2aPtr}} {
+		if direction.set {
+			names = append(names, direction.name)
+		}
+	}
+	switch len(names) {
+	case 0:
+		return language.NoDirection, messages.Errorf(messages.MissingDirection, exit.ErrUsage, longFlag("l2c"), longFlag("c2l"), longFlag("c2a"))
+	case 1:
+		direction, _ := language.ParseDirection(names[0])
+		return direction, nil
+	}
+	return language.NoDirection, messages.Errorf(messages.ConflictingFlags, exit.ErrUsage, longFlag(names[0]), longFlag(names[1]))
+}
+
+// Writes the words of the file whose letters the transliteration would change, with the line and the rule which
+// decides them. Returns the number of the words.
+func checkFile(path string, direction language.Direction) (int, error) {
+	var reader *bufio.Reader
+	if path == standardInput {
+		decoded, err := terminal.DecodeReader(os.Stdin)
+		if err != nil {
+			return 0, err
+		}
+		reader = decoded
+	} else {
+		file, decoded, err := terminal.OpenInputFile(path)
+		if err != nil {
+			return 0, err
+		}
+		defer file.Close()
+		reader = decoded
+	}
+
+	words := 0
+	scheme := dictionary.CurrentScheme()
+	for line := 1; ; line++ {
+		text, err := reader.ReadString('\n')
+		for _, explanation := range language.ExplainText(text, direction, scheme) {
+			if explanation.ChangesLetters() {
+				fmt.Printf("%s:%d: %s\n", path, line, explanation)
+				words++
+			}
+		}
+		if err == io.EOF {
+			return words, nil
+		}
+		if err != nil {
+			return words, err
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/eevan78/translit/internal/exit"
+	"github.com/eevan78/translit/internal/messages"
+	"github.com/spf13/pflag"
+)
+
+// A command of the program, with the single-dash flags it takes as long flags
+type command struct {
+	name        string
+	synopsis    messages.Key
+	description messages.Key
+	flags       []string
+	run         func(flags *pflag.FlagSet) (int, error) // returns the exit code, or the error of the flags
+}
+
+var commands = []command{
+	{"convert", messages.ConvertSynopsis, messages.ConvertDescription, []string{
+		"l2c", "c2l", "c2a", "slug", "html", "text", "i", "columns", "header", "attrs", "meta", "fragment",
+		"nolang", "stream", "from-encoding", "to-encoding", "diacritics", "lexicon", "scheme", "scheme-file",
+		"dump-scheme", "report", "explain", "interactive", "user-dict", "fail-fast", "xliff-source", "skip", "config", "profile", "ui-lang",
+	}, runConvert},
+	{"check", messages.CheckSynopsis, messages.CheckDescription, []string{
+		"l2c", "c2l", "c2a", "i", "from-encoding", "scheme", "scheme-file", "ui-lang",
+	}, runCheck},
+	{"serve", messages.ServeSynopsis, messages.ServeDescription, []string{
+		"scheme", "scheme-file", "ui-lang",
+	}, runServe},
+	{"dict", messages.DictSynopsis, messages.DictDescription, []string{
+		"user-dict", "ui-lang",
+	}, runDict},
+	{"config", messages.ConfigSynopsis, messages.ConfigDescription, []string{
+		"config", "profile", "ui-lang",
+	}, runConfig},
+}
+
+// Long names of the single-dash flags whose names are too short to be long flags. The short names stay as
+// the shorthands.
+var longNames = map[string]string{
+	"i": "input",
+}
+
+// Returns the name of the single-dash flag as a long flag.
+func longFlag(name string) string {
+	if long, ok := longNames[name]; ok {
+		return "--" + long
+	}
+	return "--" + name
+}
+
+// Runs the command with the arguments after its name. Returns the exit code.
+func runCommand(name string, arguments []string) int {
+	for _, command := range commands {
+		if command.name == name {
+			return command.execute(arguments)
+		}
+	}
+	_ = exit.Localize()
+	names := make([]string, len(commands))
+	for i, command := range commands {
+		names[i] = command.name
+	}
+	return exit.PrintUsage(messages.Errorf(messages.UnknownCommand, exit.ErrUsage, name, strings.Join(names, ", ")))
+}
+
+func (command command) execute(arguments []string) int {
+	flags := command.flagSet()
+	if err := flags.Parse(arguments); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return exit.Success
+		}
+		return command.usageError(fmt.Errorf("%w: %v", exit.ErrUsage, err))
+	}
+	if err := exit.Localize(); err != nil {
+		return command.usageError(fmt.Errorf("%w: %v", exit.ErrUsage, err))
+	}
+	code, err := command.run(flags)
+	if err != nil {
+		return flagsError(err, command.usageError)
+	}
+	return code
+}
+
+// Returns the long flags of the command, which set the same values as the single-dash flags.
+func (command command) flagSet() *pflag.FlagSet {
+	flags := pflag.NewFlagSet(command.name, pflag.ContinueOnError)
+	flags.SetOutput(os.Stderr)
+	for _, name := range command.flags {
+		option := pflag.PFlagFromGoFlag(flag.CommandLine.Lookup(name))
+		if long, ok := longNames[name]; ok {
+			option.Name, option.Shorthand = long, name
+		}
+		flags.AddFlag(option)
+	}
+	if command.name == "serve" {
+		flags.StringVar(&serveAddress, "addr", serveAddress, messages.FlagUsage("addr"))
+	}
+	flags.Usage = func() { command.printUsage(flags) }
+	return flags
+}
+
+func (command command) printUsage(flags *pflag.FlagSet) {
+	exit.Localize()
+	// the help of the flags is shown in the language selected with them
+	flags.VisitAll(func(option *pflag.Flag) {
+		name := option.Name
+		if option.Shorthand != "" {
+			name = option.Shorthand
+		}
+		if usage := messages.FlagUsage(name); usage != "" {
+			option.Usage = usage
+		}
+	})
+	messages.Fprintf(os.Stderr, messages.CommandUsage, os.Args[0], command.name, messages.Text(command.synopsis),
+		messages.Text(command.description))
+	flags.PrintDefaults()
+}
+
+// Reports what is wrong with the flags of the command, and where to find its help. Returns the exit code for
+// the wrong usage.
+func (command command) usageError(err error) int {
+	fmt.Fprintln(os.Stderr, err)
+	messages.Fprintf(os.Stderr, messages.CommandHelpHint, os.Args[0], command.name)
+	return exit.Usage
+}
+
+// Returns the input given with --input, or as the only argument.
+func inputArgument(flags *pflag.FlagSet, input *string) error {
+	switch {
+	case flags.NArg() > 1:
+		return unexpectedArguments(flags.Args()[1:])
+	case flags.NArg() == 1 && flags.Changed(longNames["i"]):
+		return messages.Errorf(messages.InputGivenTwice, exit.ErrUsage, longFlag("i"))
+	case flags.NArg() == 1:
+		*input = flags.Arg(0)
+	}
+	return nil
+}
+
+// Returns the usage error for the arguments the command does not take.
+func unexpectedArguments(arguments []string) error {
+	return messages.Errorf(messages.UnexpectedArguments, exit.ErrUsage, strings.Join(arguments, " "))
+}
+
+// Returns the values of the flags given in the command line by the names of the single-dash flags, which override
+// the configuration.
+func givenFlags(flags *pflag.FlagSet) map[string]flag.Value {
+	given := map[string]flag.Value{}
+	flags.Visit(func(option *pflag.Flag) {
+		name := option.Name
+		if option.Shorthand != "" {
+			name = option.Shorthand
+		}
+		given[name] = option.Value
+	})
+	return given
+}
+
+// Action of a command, with the number of the arguments it takes
+type commandAction struct {
+	name      string
+	arguments int
+}
+
+// Returns the action given as the first argument, which has to be one of the actions, and its arguments.
+func parseAction(flags *pflag.FlagSet, actions []commandAction) (string, []string, error) {
+	names := make([]string, len(actions))
+	for i, action := range actions {
+		names[i] = action.name
+	}
+	if flags.NArg() == 0 {
+		return "", nil, messages.Errorf(messages.MissingAction, exit.ErrUsage, strings.Join(names, ", "))
+	}
+
+	name, arguments := flags.Arg(0), flags.Args()[1:]
+	index := slices.Index(names, name)
+	if index < 0 {
+		return "", nil, messages.Errorf(messages.UnknownAction, exit.ErrUsage, name, strings.Join(names, ", "))
+	}
+	if count := actions[index].arguments; len(arguments) > count {
+		return "", nil, unexpectedArguments(arguments[count:])
+	} else if len(arguments) < count {
+		return "", nil, messages.Errorf(messages.MissingArguments, exit.ErrUsage, name)
+	}
+	return name, arguments, nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/eevan78/translit/internal/configuration"
+	"github.com/eevan78/translit/internal/dictionary"
+	"github.com/eevan78/translit/internal/exit"
+	"github.com/eevan78/translit/internal/messages"
+	"github.com/spf13/pflag"
+)
+
+// Checks the configuration file, or writes the settings which are used from it.
+func runConfig(flags *pflag.FlagSet) (int, error) {
+	action, _, err := parseAction(flags, []commandAction{{"validate", 0}, {"show", 0}})
+	if err != nil {
+		return 0, err
+	}
+	// without --config, the file is searched for like with -c
+	*dictionary.ConfigPtr = true
+
+	if action == "show" {
+		if err := configuration.ConfigInit(nil); err != nil {
+			exit.PrintError(err, "")
+			return exit.Failure, nil
+		}
+		if err := configuration.Show(os.Stdout); err != nil {
+			exit.PrintError(err, "")
+			return exit.Failure, nil
+		}
+		return exit.Success, nil
+	}
+
+	path, err := configuration.ConfigPath()
+	if err != nil {
+		exit.PrintError(err, "")
+		return exit.Failure, nil
+	}
+	version, err := configuration.Validate(path)
+	if err != nil {
+		// every error names the line of the setting
+		fmt.Fprintln(os.Stderr, err)
+		return exit.Failure, nil
+	}
+	messages.Printf(messages.ConfigValid, path, version)
+	if version != configuration.CurrentVersion {
+		messages.Fprintf(os.Stderr, messages.ConfigMigrated, path, version, configuration.CurrentVersion)
+	}
+	return exit.Success, nil
+}
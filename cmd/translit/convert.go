@@ -0,0 +1,25 @@
+package main
+
+import (
+	"github.com/eevan78/translit/internal/configuration"
+	"github.com/eevan78/translit/internal/dictionary"
+	"github.com/eevan78/translit/internal/exit"
+	"github.com/eevan78/translit/internal/terminal"
+	"github.com/spf13/pflag"
+)
+
+// Transliterates the standard input, or the input given with --input or as the argument, like the flags without
+// a command. With --config, the flags which are given override the configuration.
+func runConvert(flags *pflag.FlagSet) (int, error) {
+	if err := configuration.ConfigInit(givenFlags(flags)); err != nil {
+		exit.PrintError(err, "")
+		return exit.Failure, nil
+	}
+	if err := inputArgument(flags, dictionary.InputPathPtr); err != nil {
+		return 0, err
+	}
+	if err := terminal.CheckConvertFlags(longFlag); err != nil {
+		return 0, err
+	}
+	return transliterate(), nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/eevan78/translit/internal/dictionary"
+	"github.com/eevan78/translit/internal/exit"
+	"github.com/eevan78/translit/internal/messages"
+	"github.com/eevan78/translit/internal/userdict"
+	"github.com/spf13/pflag"
+)
+
+// Lists the words of the user dictionary, or sets or removes the decision about a word.
+func runDict(flags *pflag.FlagSet) (int, error) {
+	if *dictionary.UserDictPtr == "" {
+		return 0, messages.Errorf(messages.CommandRequires, exit.ErrUsage, "dict", longFlag("user-dict"))
+	}
+	action, arguments, err := parseAction(flags, []commandAction{{"list", 0}, {"set", 2}, {"remove", 1}})
+	if err != nil {
+		return 0, err
+	}
+
+	userDictionary, err := userdict.Load(*dictionary.UserDictPtr)
+	if err != nil {
+		exit.PrintError(err, *dictionary.UserDictPtr)
+		return exit.Failure, nil
+	}
+
+	switch action {
+	case "list":
+		for _, word := range userDictionary.Words() {
+			decision, _ := userDictionary.Lookup(word)
+			fmt.Println(word, decision)
+		}
+		return exit.Success, nil
+	case "set":
+		decision, ok := userdict.ParseDecision(arguments[1])
+		if !ok {
+			return 0, messages.Errorf(messages.UnknownDecision, exit.ErrUsage, arguments[1])
+		}
+		userDictionary.Set(arguments[0], decision)
+	case "remove":
+		if !userDictionary.Remove(arguments[0]) {
+			exit.PrintError(messages.Errorf(messages.WordNotInDictionary, arguments[0]), *dictionary.UserDictPtr)
+			return exit.Failure, nil
+		}
+	}
+
+	if err := userDictionary.Save(*dictionary.UserDictPtr); err != nil {
+		exit.PrintError(err, *dictionary.UserDictPtr)
+		return exit.Failure, nil
+	}
+	return exit.Success, nil
+}
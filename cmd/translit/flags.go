@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/eevan78/translit/internal/dictionary"
+	"github.com/eevan78/translit/internal/messages"
+)
+
+// Binds the single-dash flags of the program to the settings, so that only the program has them on the command line,
+// and not the other programs which use the library.
+func init() {
+	flag.BoolVar(dictionary.L2cPtr, "l2c", *dictionary.L2cPtr, messages.FlagUsage("l2c"))
+	flag.BoolVar(dictionary.C2lPtr, "c2l", *dictionary.C2lPtr, messages.FlagUsage("c2l"))
+	flag.BoolVar(dictionary.C2aPtr, "c2a", *dictionary.C2aPtr, messages.FlagUsage("c2a"))
+	flag.BoolVar(dictionary.SlugPtr, "slug", *dictionary.SlugPtr, messages.FlagUsage("slug"))
+	flag.BoolVar(dictionary.HtmlPtr, "html", *dictionary.HtmlPtr, messages.FlagUsage("html"))
+	flag.BoolVar(dictionary.TextPtr, "text", *dictionary.TextPtr, messages.FlagUsage("text"))
+	flag.BoolVar(dictionary.ConfigPtr, "c", *dictionary.ConfigPtr, messages.FlagUsage("c"))
+	flag.StringVar(dictionary.ConfigFilePtr, "config", *dictionary.ConfigFilePtr, messages.FlagUsage("config"))
+	flag.StringVar(dictionary.ProfilePtr, "profile", *dictionary.ProfilePtr, messages.FlagUsage("profile"))
+	flag.StringVar(dictionary.InputPathPtr, "i", *dictionary.InputPathPtr, messages.FlagUsage("i"))
+	flag.StringVar(dictionary.ColumnsPtr, "columns", *dictionary.ColumnsPtr, messages.FlagUsage("columns"))
+	flag.BoolVar(dictionary.HeaderPtr, "header", *dictionary.HeaderPtr, messages.FlagUsage("header"))
+	flag.StringVar(dictionary.HtmlAttributesPtr, "attrs", *dictionary.HtmlAttributesPtr, messages.FlagUsage("attrs"))
+	flag.StringVar(dictionary.HtmlMetaPtr, "meta", *dictionary.HtmlMetaPtr, messages.FlagUsage("meta"))
+	flag.BoolVar(dictionary.FragmentPtr, "fragment", *dictionary.FragmentPtr, messages.FlagUsage("fragment"))
+	flag.BoolVar(dictionary.NoLangPtr, "nolang", *dictionary.NoLangPtr, messages.FlagUsage("nolang"))
+	flag.BoolVar(dictionary.StreamPtr, "stream", *dictionary.StreamPtr, messages.FlagUsage("stream"))
+	flag.StringVar(dictionary.FromEncodingPtr, "from-encoding", *dictionary.FromEncodingPtr, messages.FlagUsage("from-encoding"))
+	flag.StringVar(dictionary.ToEncodingPtr, "to-encoding", *dictionary.ToEncodingPtr, messages.FlagUsage("to-encoding"))
+	flag.BoolVar(dictionary.DiacriticsPtr, "diacritics", *dictionary.DiacriticsPtr, messages.FlagUsage("diacritics"))
+	flag.StringVar(dictionary.LexiconPtr, "lexicon", *dictionary.LexiconPtr, messages.FlagUsage("lexicon"))
+	flag.StringVar(dictionary.SchemePtr, "scheme", *dictionary.SchemePtr, messages.FlagUsage("scheme"))
+	flag.StringVar(dictionary.SchemeFilePtr, "scheme-file", *dictionary.SchemeFilePtr, messages.FlagUsage("scheme-file"))
+	flag.StringVar(dictionary.DumpSchemePtr, "dump-scheme", *dictionary.DumpSchemePtr, messages.FlagUsage("dump-scheme"))
+	flag.StringVar(dictionary.ReportPtr, "report", *dictionary.ReportPtr, messages.FlagUsage("report"))
+	flag.BoolVar(dictionary.ExplainPtr, "explain", *dictionary.ExplainPtr, messages.FlagUsage("explain"))
+	flag.BoolVar(dictionary.InteractivePtr, "interactive", *dictionary.InteractivePtr, messages.FlagUsage("interactive"))
+	flag.StringVar(dictionary.UserDictPtr, "user-dict", *dictionary.UserDictPtr, messages.FlagUsage("user-dict"))
+	flag.BoolVar(dictionary.FailFastPtr, "fail-fast", *dictionary.FailFastPtr, messages.FlagUsage("fail-fast"))
+	flag.StringVar(dictionary.SkipPtr, "skip", *dictionary.SkipPtr, messages.FlagUsage("skip"))
+	flag.BoolVar(dictionary.XliffSourcePtr, "xliff-source", *dictionary.XliffSourcePtr, messages.FlagUsage("xliff-source"))
+	flag.StringVar(dictionary.UiLangPtr, "ui-lang", *dictionary.UiLangPtr, messages.FlagUsage("ui-lang"))
+}
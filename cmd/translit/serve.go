@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/eevan78/translit/internal/dictionary"
+	"github.com/eevan78/translit/internal/language"
+	"github.com/eevan78/translit/internal/messages"
+	"github.com/eevan78/translit/internal/terminal"
+	"github.com/spf13/pflag"
+)
+
+// Address the transliteration server listens on, given with --addr
+var serveAddress = "localhost:8080"
+
+const (
+	maxRequestSize     = 1 << 20 // bytes of the text in a request
+	serveHeaderTimeout = 10 * time.Second
+	serveTimeout       = 30 * time.Second
+)
+
+// Starts the transliteration server with the scheme given with the flags as the default one.
+func runServe(flags *pflag.FlagSet) (int, error) {
+	if flags.NArg() > 0 {
+		return 0, unexpectedArguments(flags.Args())
+	}
+	if err := terminal.CheckScheme(); err != nil {
+		return 0, err
+	}
+	messages.Fprintf(os.Stderr, messages.Listening, serveAddress)
+	// the timeouts keep the slow clients from holding the connections open
+	server := &http.Server{
+		Addr:              serveAddress,
+		Handler:           newServeMux(*dictionary.SchemePtr),
+		ReadHeaderTimeout: serveHeaderTimeout,
+		ReadTimeout:       serveTimeout,
+		WriteTimeout:      serveTimeout,
+	}
+	return 0, server.ListenAndServe()
+}
+
+// Returns the handler which transliterates the body of a POST request in the direction from the path, like /l2c,
+// with the scheme from the scheme parameter, or the default one. The body is limited to maxRequestSize bytes.
+func newServeMux(defaultScheme string) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPost {
+			writer.Header().Set("Allow", http.MethodPost)
+			http.Error(writer, messages.Text(messages.OnlyPost), http.StatusMethodNotAllowed)
+			return
+		}
+		name := request.URL.Path[1:]
+		direction, ok := language.ParseDirection(name)
+		if !ok {
+			http.Error(writer, messages.Sprintf(messages.UnknownDirection, name), http.StatusNotFound)
+			return
+		}
+
+		schemeName := request.URL.Query().Get("scheme")
+		if schemeName == "" {
+			schemeName = defaultScheme
+		}
+		scheme, ok := dictionary.Schemes[schemeName]
+		if !ok {
+			http.Error(writer, messages.Sprintf(messages.UnknownSchemeParameter, schemeName, strings.Join(dictionary.SchemeNames(), ", ")), http.StatusBadRequest)
+			return
+		}
+		if scheme.L2c == nil && direction == language.LatinToCyrillic {
+			http.Error(writer, messages.Sprintf(messages.LatinOnlySchemeParameter, schemeName), http.StatusBadRequest)
+			return
+		}
+
+		text, err := io.ReadAll(http.MaxBytesReader(writer, request.Body, maxRequestSize))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(writer, messages.Sprintf(messages.TextTooLarge, tooLarge.Limit), http.StatusRequestEntityTooLarge)
+			return
+		} else if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writer.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		io.WriteString(writer, language.TransliterateText(string(text), direction, scheme))
+	})
+}
@@ -6,22 +6,73 @@
 package main
 
 import (
+	"errors"
+	"os"
+	"strings"
+
 	"github.com/eevan78/translit/internal/configuration"
+	"github.com/eevan78/translit/internal/dictionary"
+	"github.com/eevan78/translit/internal/exit"
 	"github.com/eevan78/translit/internal/language"
 	"github.com/eevan78/translit/internal/terminal"
 )
 
 func main() {
+	if code := run(); code != exit.Success {
+		os.Exit(code)
+	}
+}
+
+// Runs the program and returns its exit code. The program is called with a command and long flags, or only with
+// the single-dash flags, like before the commands.
+func run() int {
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		return runCommand(os.Args[1], os.Args[2:])
+	}
+
 	terminal.ProcessFlags()
 
-	configuration.ConfigInit()
+	if err := configuration.ConfigInit(terminal.GivenFlags()); err != nil {
+		exit.PrintError(err, "")
+		return exit.Failure
+	}
+
+	if err := terminal.CheckFlags(); err != nil {
+		return flagsError(err, exit.PrintUsage)
+	}
+	return transliterate()
+}
+
+// Reports the error of the flags, with the help when the flags are used the wrong way. Returns the exit code.
+func flagsError(err error, usage func(error) int) int {
+	if errors.Is(err, exit.ErrUsage) {
+		return usage(err)
+	}
+	exit.PrintError(err, "")
+	return exit.Failure
+}
 
-	terminal.CheckFlags()
+// Transliterates the input given with the checked flags, or writes the scheme out. Returns the exit code.
+func transliterate() int {
+	if dumped, err := terminal.DumpScheme(); dumped {
+		if err != nil {
+			exit.PrintError(err, "")
+			return exit.Failure
+		}
+		return exit.Success
+	}
 
-	terminal.ProcessFilePaths()
+	if err := terminal.ProcessFilePaths(); err != nil {
+		exit.PrintError(err, *dictionary.InputPathPtr)
+		return exit.Failure
+	}
 
-	documents := language.CreateDocuments()
+	documents, summary := language.CreateDocuments()
 
-	language.Transliterate(documents)
+	if !summary.Stopped() {
+		summary.Add(language.Transliterate(documents))
+	}
 
+	summary.PrintFailures()
+	return summary.ExitCode()
 }
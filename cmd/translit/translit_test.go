@@ -2,18 +2,25 @@ package main
 
 import (
 	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/eevan78/translit/internal/configuration"
 	"github.com/eevan78/translit/internal/dictionary"
+	"github.com/eevan78/translit/internal/exit"
+	"github.com/eevan78/translit/internal/language"
+	"github.com/eevan78/translit/internal/messages"
 	"github.com/eevan78/translit/internal/terminal"
 )
 
@@ -109,6 +116,100 @@ func TestL2CHtmlInputFileFromInternetWithTrailingSlash(t *testing.T) {
 
 }
 
+// The lang and translate attributes are inherited from the distant ancestors and overridden by the nested ones,
+// and the text of the elements for the computer code and the user input is never transliterated.
+func TestL2CHtmlLanguageInputFile(t *testing.T) {
+	*dictionary.L2cPtr = true
+	*dictionary.C2lPtr = false
+	*dictionary.InputPathPtr = "../../test/testdata/jezici.html"
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/jezici_izlaz.html")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+}
+
+func TestL2CHtmlNoLangInputFile(t *testing.T) {
+	*dictionary.L2cPtr = true
+	*dictionary.C2lPtr = false
+	*dictionary.InputPathPtr = "../../test/testdata/stranica.html"
+	*dictionary.NoLangPtr = true
+	defer func() { *dictionary.NoLangPtr = false }()
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/stranica_izlaz.html")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+}
+
+func TestL2CHtmlStreamNoLangInputFile(t *testing.T) {
+	*dictionary.L2cPtr = true
+	*dictionary.C2lPtr = false
+	*dictionary.InputPathPtr = "../../test/testdata/tok.html"
+	*dictionary.StreamPtr = true
+	*dictionary.NoLangPtr = true
+	defer func() { *dictionary.StreamPtr, *dictionary.NoLangPtr = false, false }()
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/tok_izlaz.html")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+}
+
+func TestL2CHtmlFragmentInputFile(t *testing.T) {
+	*dictionary.L2cPtr = true
+	*dictionary.C2lPtr = false
+	*dictionary.InputPathPtr = "../../test/testdata/odlomak.html"
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/odlomak_izlaz.html")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+}
+
+func TestL2CHtmlAttributesInputFile(t *testing.T) {
+	*dictionary.L2cPtr = true
+	*dictionary.C2lPtr = false
+	*dictionary.InputPathPtr = "../../test/testdata/atributi.html"
+	defer restoreFlags()()
+	// value is transliterated only on the buttons, while aria-label and og:title are not listed
+	*dictionary.HtmlAttributesPtr = "title,alt,value"
+	*dictionary.HtmlMetaPtr = "description,author"
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/atributi_izlaz.html")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+}
+
+func TestL2CHtmlStreamAttributesInputFile(t *testing.T) {
+	*dictionary.L2cPtr = true
+	*dictionary.C2lPtr = false
+	*dictionary.InputPathPtr = "../../test/testdata/atributi.html"
+	defer restoreFlags()()
+	// the same attributes as in the tree mode, with the markup kept as it is
+	*dictionary.StreamPtr = true
+	*dictionary.HtmlAttributesPtr = "title,alt,value"
+	*dictionary.HtmlMetaPtr = "description,author"
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/atributi_tok_izlaz.html")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+}
+
 func TestL2CTextInputFile(t *testing.T) {
 	*dictionary.L2cPtr = true
 	*dictionary.C2lPtr = false
@@ -135,6 +236,570 @@ func TestL2CXmlInputFile(t *testing.T) {
 	compareExpected(t, expectedOutput)
 }
 
+// The language is inherited from xml:lang or lang of an ancestor: a Serbian element inside a foreign one is
+// transliterated, while a foreign element inside a Serbian one is not.
+func TestL2CXmlLanguageInputFile(t *testing.T) {
+	*dictionary.L2cPtr = true
+	*dictionary.C2lPtr = false
+	*dictionary.InputPathPtr = "../../test/testdata/jezici.xml"
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/jezici_izlaz.xml")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+}
+
+func TestL2CCsvInputFileWithColumns(t *testing.T) {
+	*dictionary.L2cPtr = true
+	*dictionary.C2lPtr = false
+	*dictionary.InputPathPtr = "../../test/testdata/katalog.csv"
+	*dictionary.ColumnsPtr = "naziv,3"
+	defer func() { *dictionary.ColumnsPtr = "" }()
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/katalog_izlaz.csv")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+}
+
+func TestL2CCsvInputFileWithSingleQuotes(t *testing.T) {
+	*dictionary.L2cPtr = true
+	*dictionary.C2lPtr = false
+	*dictionary.InputPathPtr = "../../test/testdata/cenovnik.csv"
+	*dictionary.ColumnsPtr = "naziv"
+	defer func() { *dictionary.ColumnsPtr = "" }()
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/cenovnik_izlaz.csv")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+}
+
+func TestC2LXliffInputFile(t *testing.T) {
+	*dictionary.L2cPtr = false
+	*dictionary.C2lPtr = true
+	*dictionary.InputPathPtr = "../../test/testdata/xliff12.xlf"
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/xliff12_izlaz.xlf")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+}
+
+func TestL2CXliff20InputFile(t *testing.T) {
+	*dictionary.L2cPtr = true
+	*dictionary.C2lPtr = false
+	*dictionary.InputPathPtr = "../../test/testdata/xliff20.xlf"
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/xliff20_izlaz.xlf")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+}
+
+func TestC2LXliff20TargetFromSourceInputFile(t *testing.T) {
+	*dictionary.L2cPtr = false
+	*dictionary.C2lPtr = true
+	*dictionary.InputPathPtr = "../../test/testdata/xliff20_izvor.xlf"
+	*dictionary.SchemePtr = "bgn"
+	defer func() { *dictionary.SchemePtr = "sr" }()
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/xliff20_izvor_izlaz.xlf")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+}
+
+func TestL2CLegacyEncodedTextInputFile(t *testing.T) {
+	*dictionary.L2cPtr = true
+	*dictionary.C2lPtr = false
+	*dictionary.InputPathPtr = "../../test/testdata/vest_cp1250.txt"
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/vest_cp1250_izlaz.txt")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+}
+
+func TestL2CYusciiTextInputFile(t *testing.T) {
+	*dictionary.L2cPtr = true
+	*dictionary.C2lPtr = false
+	*dictionary.InputPathPtr = "../../test/testdata/pesma_yuscii.txt"
+	*dictionary.FromEncodingPtr = "yuscii-latin"
+	defer func() { *dictionary.FromEncodingPtr = "" }()
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/pesma_yuscii_izlaz.txt")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+}
+
+func TestL2CWithDiacriticsTextInputFile(t *testing.T) {
+	*dictionary.L2cPtr = true
+	*dictionary.C2lPtr = false
+	*dictionary.InputPathPtr = "../../test/testdata/bez_kvacica.txt"
+	*dictionary.DiacriticsPtr = true
+	defer func() { *dictionary.DiacriticsPtr = false }()
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/bez_kvacica_izlaz.txt")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+}
+
+func TestC2AMixedTextInputFile(t *testing.T) {
+	*dictionary.L2cPtr = false
+	*dictionary.C2lPtr = false
+	*dictionary.C2aPtr = true
+	defer func() { *dictionary.C2aPtr = false }()
+	*dictionary.InputPathPtr = "../../test/testdata/imena.txt"
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/imena_izlaz.txt")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+}
+
+func TestL2CMontenegrinTextInputFile(t *testing.T) {
+	*dictionary.L2cPtr = true
+	*dictionary.C2lPtr = false
+	*dictionary.InputPathPtr = "../../test/testdata/crnogorski.txt"
+	*dictionary.SchemePtr = "cnr"
+	defer func() { *dictionary.SchemePtr = "sr" }()
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/crnogorski_izlaz.txt")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+}
+
+func TestC2LMacedonianTextInputFile(t *testing.T) {
+	*dictionary.L2cPtr = false
+	*dictionary.C2lPtr = true
+	*dictionary.InputPathPtr = "../../test/testdata/makedonski.txt"
+	*dictionary.SchemePtr = "mk"
+	defer func() { *dictionary.SchemePtr = "sr" }()
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/makedonski_izlaz.txt")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+}
+
+func TestC2LDigraphCaseTextInputFile(t *testing.T) {
+	*dictionary.L2cPtr = false
+	*dictionary.C2lPtr = true
+	*dictionary.InputPathPtr = "../../test/testdata/naslovi.txt"
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/naslovi_izlaz.txt")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+}
+
+func TestL2CReportTextInputFile(t *testing.T) {
+	*dictionary.L2cPtr = true
+	*dictionary.C2lPtr = false
+	*dictionary.InputPathPtr = "../../test/testdata/izvestaj.txt"
+	*dictionary.ReportPtr = filepath.Join(t.TempDir(), "izvestaj.json")
+	defer func() { *dictionary.ReportPtr = "" }()
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/izvestaj_izlaz.txt")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+
+	data, err := os.ReadFile(*dictionary.ReportPtr)
+	if err != nil {
+		t.Fatalf("Транслит није направио извештај: %v", err)
+	}
+	report := language.Report{}
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("Извештај није исправан JSON: %v", err)
+	}
+	if len(report.Documents) != 1 || report.Direction != "l2c" || report.Scheme != "sr" {
+		t.Fatalf("Неочекиван извештај:\n%s", data)
+	}
+	stats := report.Totals
+	if stats.Words != 4 || stats.Units != 1 || stats.DigraphSplits != 2 || stats.Protected != 1 ||
+		stats.Foreign["foreign_character_combinations"] != 1 || stats.Foreign["common_foreign_words"] != 1 ||
+		stats.Foreign["whole_foreign_words"] != 2 {
+		t.Fatalf("Неочекиван број речи у извештају:\n%s", data)
+	}
+}
+
+func TestL2CUserDictTextInputFile(t *testing.T) {
+	*dictionary.L2cPtr = true
+	*dictionary.C2lPtr = false
+	*dictionary.InputPathPtr = "../../test/testdata/odluke.txt"
+	*dictionary.UserDictPtr = "../../test/testdata/korisnicki_recnik.txt"
+	defer func() { *dictionary.UserDictPtr = "" }()
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/odluke_izlaz.txt")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+}
+
+func TestL2CSchemeFileTextInputFile(t *testing.T) {
+	*dictionary.L2cPtr = true
+	*dictionary.C2lPtr = false
+	*dictionary.InputPathPtr = "../../test/testdata/sema.txt"
+	*dictionary.SchemeFilePtr = "../../test/testdata/sema.yaml"
+	defer func() {
+		*dictionary.SchemeFilePtr = ""
+		*dictionary.SchemePtr = "sr"
+		delete(dictionary.Schemes, "sr-akcenti")
+	}()
+	flag.Parse()
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/sema_izlaz.txt")
+
+	main()
+
+	compareExpected(t, expectedOutput)
+}
+
+func TestL2CDirectoryWithBrokenFile(t *testing.T) {
+	*dictionary.L2cPtr = true
+	*dictionary.C2lPtr = false
+	*dictionary.InputPathPtr = "../../test/testdata/greske"
+	flag.Parse()
+	defer cleanOutput()
+
+	outDirPath, _ := filepath.Abs(filepath.Join("../../test/testdata", terminal.OutputDir))
+	expectedOutput, _ := filepath.Abs("../../test/testdata/rec_godine_izlaz.txt")
+
+	if code := run(); code != exit.PartialFailure {
+		t.Fatalf("Статус изласка је %d, а требало је да буде %d", code, exit.PartialFailure)
+	}
+
+	// the broken file leaves no output behind, and the rest of the directory is transliterated
+	if !isExist(filepath.Join(outDirPath, "pokvareno.xml")) {
+		t.Fatalf("Остао је излаз фајла који није пресловљен")
+	}
+	transliterated, err := os.ReadFile(filepath.Join(outDirPath, "rec_godine.txt"))
+	if err != nil {
+		t.Fatalf("Транслит није пресловио остале фајлове: %v", err)
+	}
+	expected, err := os.ReadFile(expectedOutput)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if string(transliterated) != string(expected) {
+		t.Fatalf("Садржај пресловљеног фајла се разликује од очекиваног!")
+	}
+}
+
+func TestMessageCatalogs(t *testing.T) {
+	cyrillic := messages.Catalog(messages.SerbianCyrillic)
+	latin := messages.Catalog(messages.SerbianLatin)
+	english := messages.Catalog(messages.English)
+
+	for key, message := range cyrillic {
+		// the messages in Serbian Latin are generated with go generate ./internal/messages
+		if expected := language.TransliterateText(message, language.CyrillicToLatin, dictionary.Schemes["sr"]); latin[key] != expected {
+			t.Errorf("Порука %s на латиници није поново направљена: %q, а очекује се %q", key, latin[key], expected)
+		}
+		if _, ok := english[key]; !ok {
+			t.Errorf("Порука %s није преведена на енглески", key)
+		}
+	}
+	if len(latin) != len(cyrillic) || len(english) != len(cyrillic) {
+		t.Errorf("Поруке на латиници или енглеском нису и на ћирилици")
+	}
+}
+
+func TestUiLanguage(t *testing.T) {
+	defer messages.Select(messages.SerbianCyrillic)
+
+	for tag, expected := range map[string]string{
+		"sr":                messages.SerbianCyrillic,
+		"sr-Latn":           messages.SerbianLatin,
+		"sr_RS.UTF-8@latin": messages.SerbianLatin,
+		"sr_RS.UTF-8":       messages.SerbianCyrillic,
+		"en_US.UTF-8":       messages.English,
+	} {
+		if err := messages.Select(tag); err != nil || messages.Language() != expected {
+			t.Errorf("За %s је изабран језик %s (%v), а очекује се %s", tag, messages.Language(), err, expected)
+		}
+	}
+	if err := messages.Select("de"); err == nil {
+		t.Errorf("Језик de није пријављен као непознат")
+	}
+
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "de_DE.UTF-8")
+	t.Setenv("LANG", "sr_RS.UTF-8")
+	if messages.Select(""); messages.Language() != messages.English {
+		t.Errorf("За LC_MESSAGES=de_DE.UTF-8 је изабран језик %s, а очекује се %s", messages.Language(), messages.English)
+	}
+}
+
+func TestConfigFile(t *testing.T) {
+	defer restoreFlags()()
+
+	*dictionary.ConfigFilePtr = "../../test/testdata/nema.yaml"
+	if err := configuration.ConfigInit(nil); err == nil {
+		t.Fatalf("Није пријављено да конфигурациони фајл не постоји")
+	}
+
+	// the environment overrides the scheme from the file
+	*dictionary.ConfigFilePtr = "../../test/testdata/konfiguracija.yaml"
+	t.Setenv("TRANSLIT_SCHEME", "cnr")
+	if err := configuration.ConfigInit(nil); err != nil {
+		t.Fatalf("Конфигурација није прочитана: %v", err)
+	}
+	if *dictionary.SchemePtr != "cnr" {
+		t.Fatalf("Шема је %s, а требало је да буде cnr", *dictionary.SchemePtr)
+	}
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/crnogorski_izlaz.txt")
+
+	if code := transliterate(); code != exit.Success {
+		t.Fatalf("Статус изласка је %d, а требало је да буде %d", code, exit.Success)
+	}
+
+	compareExpected(t, expectedOutput)
+}
+
+func TestConfigProfile(t *testing.T) {
+	defer restoreFlags()()
+
+	*dictionary.ConfigFilePtr = "../../test/testdata/konfiguracija.yaml"
+	*dictionary.ProfilePtr = "nema"
+	if err := configuration.ConfigInit(nil); err == nil {
+		t.Fatalf("Није пријављено да профил не постоји")
+	}
+
+	// the flag from the command line overrides the scheme from the profile
+	*dictionary.ProfilePtr = "latinica"
+	*dictionary.SchemePtr = "mk"
+	if err := configuration.ConfigInit(map[string]flag.Value{"scheme": flag.Lookup("scheme").Value}); err != nil {
+		t.Fatalf("Конфигурација није прочитана: %v", err)
+	}
+	if *dictionary.L2cPtr || !*dictionary.C2lPtr || *dictionary.SchemePtr != "mk" {
+		t.Fatalf("Профил није примењен: l2c %v, c2l %v, шема %s", *dictionary.L2cPtr, *dictionary.C2lPtr, *dictionary.SchemePtr)
+	}
+
+	expectedOutput, _ := filepath.Abs("../../test/testdata/makedonski_izlaz.txt")
+
+	if code := transliterate(); code != exit.Success {
+		t.Fatalf("Статус изласка је %d, а требало је да буде %d", code, exit.Success)
+	}
+
+	compareExpected(t, expectedOutput)
+}
+
+// The environment variables override the defaults without a configuration file, but not the flags.
+func TestConfigEnvironment(t *testing.T) {
+	defer restoreFlags()()
+
+	t.Setenv("TRANSLIT_SCHEME", "cnr")
+	t.Setenv("TRANSLIT_C2L", "true")
+	if err := configuration.ConfigInit(nil); err != nil {
+		t.Fatalf("Променљиве окружења нису прочитане: %v", err)
+	}
+	if *dictionary.SchemePtr != "cnr" || !*dictionary.C2lPtr {
+		t.Fatalf("Променљиве окружења нису примењене: шема %s, c2l %v", *dictionary.SchemePtr, *dictionary.C2lPtr)
+	}
+
+	*dictionary.SchemePtr = "mk"
+	*dictionary.L2cPtr = true
+	given := map[string]flag.Value{"scheme": flag.Lookup("scheme").Value, "l2c": flag.Lookup("l2c").Value}
+	if err := configuration.ConfigInit(given); err != nil {
+		t.Fatalf("Променљиве окружења нису прочитане: %v", err)
+	}
+	if *dictionary.SchemePtr != "mk" || !*dictionary.L2cPtr || *dictionary.C2lPtr {
+		t.Fatalf("Заставице нису замениле променљиве окружења: шема %s, l2c %v, c2l %v", *dictionary.SchemePtr, *dictionary.L2cPtr, *dictionary.C2lPtr)
+	}
+
+	t.Setenv("TRANSLIT_C2L", "maybe")
+	if err := configuration.ConfigInit(nil); err == nil {
+		t.Fatalf("Није пријављена погрешна вредност променљиве окружења")
+	}
+}
+
+func TestConfigValidation(t *testing.T) {
+	defer restoreFlags()()
+	defer messages.Select(messages.SerbianCyrillic)
+	messages.Select(messages.English)
+
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "neispravna.yaml")
+	os.WriteFile(invalid, []byte("version: \"v0.9.0\"\nL2cPtr: true\nC2lPtr: true\nFoo: 1\nprofiles:\n  web:\n    HtmlPtr: maybe\n    TextPtr: yes\n    NoLangPtr: \"true\"\n"), 0644)
+	_, err := configuration.Validate(invalid)
+	if err == nil {
+		t.Fatalf("Неисправна конфигурација није пријављена")
+	}
+	for _, expected := range []string{
+		invalid + ":1: unknown version v0.9.0",
+		invalid + ":3: settings L2cPtr and C2lPtr cannot both be true",
+		invalid + ":4: unknown setting Foo",
+		invalid + ":7: setting HtmlPtr has to be true or false",
+		invalid + ":8: setting TextPtr has to be true or false",
+		invalid + ":9: setting NoLangPtr has to be true or false",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Грешка %q није пријављена у:\n%v", expected, err)
+		}
+	}
+
+	// the older version is migrated, keeping the output directory it had by default
+	old := filepath.Join(dir, "stara.yaml")
+	os.WriteFile(old, []byte("L2cPtr: true\nTextPtr: true\n"), 0644)
+	if version, err := configuration.Validate(old); err != nil || version != "v0.3.0" {
+		t.Fatalf("Верзија старе конфигурације је %s (%v)", version, err)
+	}
+	*dictionary.ConfigFilePtr = old
+	if err := configuration.ConfigInit(nil); err != nil {
+		t.Fatalf("Стара конфигурација није прочитана: %v", err)
+	}
+	if dictionary.ConfigVersion != configuration.CurrentVersion || terminal.OutputDir != "../../output" {
+		t.Fatalf("Стара конфигурација није преведена: верзија %s, излазни директоријум %s", dictionary.ConfigVersion, terminal.OutputDir)
+	}
+}
+
+func TestConflictingLongFlags(t *testing.T) {
+	defer func() { *dictionary.L2cPtr, *dictionary.C2lPtr = false, false }()
+
+	if code := runCommand("convert", []string{"--l2c", "--c2l"}); code != exit.Usage {
+		t.Fatalf("Статус изласка је %d, а требало је да буде %d", code, exit.Usage)
+	}
+	err := terminal.CheckConvertFlags(longFlag)
+	if !errors.Is(err, exit.ErrUsage) || !strings.Contains(err.Error(), "--l2c") || !strings.Contains(err.Error(), "--c2l") {
+		t.Fatalf("Грешка не именује заставице које се искључују: %v", err)
+	}
+}
+
+func TestDictCommand(t *testing.T) {
+	userDict := filepath.Join(t.TempDir(), "recnik.txt")
+
+	if code := runCommand("dict", []string{"--user-dict", userDict, "set", "Facebook", "protect"}); code != exit.Success {
+		t.Fatalf("Статус изласка је %d, а требало је да буде %d", code, exit.Success)
+	}
+	if code := runCommand("dict", []string{"--user-dict", userDict, "set", "Facebook", "keep"}); code != exit.Usage {
+		t.Fatalf("Непозната одлука није пријављена: статус изласка је %d", code)
+	}
+	if data, _ := os.ReadFile(userDict); !strings.Contains(string(data), "facebook protect\n") {
+		t.Fatalf("Одлука није записана у речник:\n%s", data)
+	}
+	if code := runCommand("dict", []string{"--user-dict", userDict, "remove", "facebook"}); code != exit.Success {
+		t.Fatalf("Статус изласка је %d, а требало је да буде %d", code, exit.Success)
+	}
+	if code := runCommand("dict", []string{"--user-dict", userDict, "remove", "facebook"}); code != exit.Failure {
+		t.Fatalf("Уклањање речи које нема није пријављено: статус изласка је %d", code)
+	}
+	*dictionary.UserDictPtr = ""
+}
+
+func TestCheckCommand(t *testing.T) {
+	defer restoreFlags()()
+	*dictionary.InputPathPtr = ""
+	dir := t.TempDir()
+
+	// the quotes and the ellipsis would be changed by the transliteration, but the letters would not
+	converted := filepath.Join(dir, "cirilica.txt")
+	os.WriteFile(converted, []byte("Рекао је \"Добар дан\" и 'здраво'...\n"), 0644)
+	if code := runCommand("check", []string{"--l2c", converted}); code != exit.Success {
+		t.Fatalf("Статус изласка је %d, а требало је да буде %d", code, exit.Success)
+	}
+
+	latin := filepath.Join(dir, "latinica.txt")
+	os.WriteFile(latin, []byte("Рекао је \"Dobar дан\"\n"), 0644)
+	if code := runCommand("check", []string{"--l2c", latin}); code != exit.Untransliterated {
+		t.Fatalf("Статус изласка је %d, а требало је да буде %d", code, exit.Untransliterated)
+	}
+}
+
+func TestConvertToLegacyEncoding(t *testing.T) {
+	defer restoreFlags()()
+	*dictionary.InputPathPtr = ""
+	dir := t.TempDir()
+
+	// the Cyrillic letters do not exist in windows-1250, so they are written as character references only in HTML
+	page := filepath.Join(dir, "strana.html")
+	os.WriteFile(page, []byte("<p>Beograd</p>\n"), 0644)
+	if code := runCommand("convert", []string{"--l2c", "--to-encoding", "windows-1250", page}); code != exit.Success {
+		t.Fatalf("Статус изласка је %d, а требало је да буде %d", code, exit.Success)
+	}
+	if output, _ := os.ReadFile(filepath.Join(dir, terminal.OutputDir, "strana.html")); !strings.Contains(string(output), "&#1041;") {
+		t.Errorf("Слова која не постоје у кодирању нису записана као референце:\n%s", output)
+	}
+
+	clearData()
+	text := filepath.Join(dir, "tekst.txt")
+	os.WriteFile(text, []byte("Beograd\n"), 0644)
+	if code := runCommand("convert", []string{"--l2c", "--to-encoding", "windows-1250", text}); code != exit.Failure {
+		t.Fatalf("Статус изласка је %d, а требало је да буде %d", code, exit.Failure)
+	}
+	if _, err := os.Stat(filepath.Join(dir, terminal.OutputDir, "tekst.txt")); err == nil {
+		t.Errorf("Остао је излазни фајл текста који није могао да се запише у кодирању")
+	}
+	clearData()
+}
+
+func TestServe(t *testing.T) {
+	server := httptest.NewServer(newServeMux("sr"))
+	defer server.Close()
+
+	for _, test := range []struct {
+		path, body, expected string
+		status               int
+	}{
+		{"/l2c", "Ovo je računar", "Ово је рачунар", http.StatusOK},
+		{"/c2l?scheme=cnr", "Сјутра", "Sjutra", http.StatusOK},
+		{"/c2a", "Ђурђевдан", "Djurdjevdan", http.StatusOK},
+		{"/l2c?scheme=iso9", "Ovo", "", http.StatusBadRequest},
+		{"/l2x", "Ovo", "", http.StatusNotFound},
+		{"/l2c", strings.Repeat("a", maxRequestSize+1), "", http.StatusRequestEntityTooLarge},
+	} {
+		response, err := http.Post(server.URL+test.path, "text/plain", strings.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(response.Body)
+		response.Body.Close()
+		if response.StatusCode != test.status || test.status == http.StatusOK && string(body) != test.expected {
+			t.Errorf("За %s је одговор %d %q, а очекује се %d %q", test.path, response.StatusCode, body, test.status, test.expected)
+		}
+	}
+
+	if response, err := http.Get(server.URL + "/l2c"); err != nil || response.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("Захтев GET није одбијен: %v", err)
+	}
+}
+
 func compareExpected(t *testing.T, expectedOutput string) {
 	exist := isOutputFileExist()
 	defer cleanOutput()
@@ -172,6 +837,18 @@ func clearData() {
 	terminal.OutputFilePaths = nil
 }
 
+// Returns the function which restores the values of all the flags, after they are set from the configuration.
+func restoreFlags() func() {
+	values := map[flag.Value]string{}
+	flag.VisitAll(func(f *flag.Flag) { values[f.Value] = f.Value.String() })
+	return func() {
+		for value, text := range values {
+			value.Set(text)
+		}
+		terminal.OutputDir = "output"
+	}
+}
+
 func cleanOutput() {
 	clearData()
 	outDirPath := filepath.Join(filepath.Dir(*dictionary.InputPathPtr), terminal.OutputDir)
@@ -10,28 +10,29 @@ import (
 )
 
 // Source: https://stackoverflow.com/a/24792688/6359607
-func Unzip(src, dest string) error {
+// The errors of closing the files are returned like the other errors.
+func Unzip(src, dest string) (err error) {
 	r, err := zip.OpenReader(src)
 	if err != nil {
 		return err
 	}
 	defer func() {
-		if err := r.Close(); err != nil {
-			panic(err)
+		if closeErr := r.Close(); err == nil {
+			err = closeErr
 		}
 	}()
 
 	os.MkdirAll(dest, 0755)
 
 	// Closure to address file descriptors issue with all the deferred .Close() methods
-	extractAndWriteFile := func(f *zip.File) error {
+	extractAndWriteFile := func(f *zip.File) (err error) {
 		rc, err := f.Open()
 		if err != nil {
 			return err
 		}
 		defer func() {
-			if err := rc.Close(); err != nil {
-				panic(err)
+			if closeErr := rc.Close(); err == nil {
+				err = closeErr
 			}
 		}()
 
@@ -51,8 +52,8 @@ func Unzip(src, dest string) error {
 				return err
 			}
 			defer func() {
-				if err := f.Close(); err != nil {
-					panic(err)
+				if closeErr := f.Close(); err == nil {
+					err = closeErr
 				}
 			}()
 
@@ -2,10 +2,11 @@ package archive
 
 import (
 	"archive/zip"
-	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+
+	"github.com/eevan78/translit/internal/messages"
 )
 
 func Zip(source, target string) error {
@@ -16,7 +17,7 @@ func Zip(source, target string) error {
 	}
 	defer f.Close()
 
-	fmt.Println("Архивирање\nу", target)
+	messages.Printf(messages.Archiving, target)
 
 	writer := zip.NewWriter(f)
 	defer writer.Close()
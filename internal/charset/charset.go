@@ -0,0 +1,260 @@
+// Package charset detects character encodings of the input and converts between them and UTF-8.
+package charset
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/eevan78/translit/internal/messages"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+	textunicode "golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+const (
+	UTF8       = "utf-8"
+	SampleSize = 4096 // how much of the input is inspected to detect the encoding
+)
+
+var (
+	declaredHtmlCharset = regexp.MustCompile(`(?i)<meta[^>]+charset\s*=\s*["']?\s*([\w:.-]+)`)
+	declaredXmlEncoding = regexp.MustCompile(`(?i)<\?xml[^>]+encoding\s*=\s*["']([\w:.-]+)["']`)
+
+	// Legacy encodings used for Serbian texts, in the order of preference when they score the same
+	legacyEncodings = []string{"windows-1250", "iso-8859-2", "windows-1251", "iso-8859-5", "koi8-r", "koi8-u"}
+
+	serbianLatinLetters    = "abcčćdđefghijklmnoprsštuvzžqwxy"
+	serbianCyrillicLetters = "абвгдђежзијклљмнњопрстћуфхцчџш"
+)
+
+// Returns the canonical name of the encoding, or an error if it is not known.
+func Name(name string) (string, error) {
+	if strings.EqualFold(strings.TrimSpace(name), "utf-16") {
+		return "utf-16", nil
+	}
+	if canonical, ok := isYuscii(name); ok {
+		return canonical, nil
+	}
+	enc, err := htmlindex.Get(strings.TrimSpace(name))
+	if err != nil {
+		return "", messages.Errorf(messages.UnknownEncoding, name)
+	}
+	canonical, err := htmlindex.Name(enc)
+	if err != nil {
+		return "", messages.Errorf(messages.UnknownEncoding, name)
+	}
+	return strings.ToLower(canonical), nil
+}
+
+// Reports whether two encoding names stand for the same encoding.
+func Same(first string, second string) bool {
+	firstName, err := Name(first)
+	if err != nil {
+		return false
+	}
+	secondName, err := Name(second)
+	if err != nil {
+		return false
+	}
+	return firstName == secondName || (strings.HasPrefix(firstName, "utf-16") && strings.HasPrefix(secondName, "utf-16"))
+}
+
+func IsUTF8(name string) bool {
+	return Same(name, UTF8)
+}
+
+func lookup(name string) (encoding.Encoding, error) {
+	canonical, err := Name(name)
+	if err != nil {
+		return nil, err
+	}
+	if canonical == "utf-16" {
+		// written with the byte order mark, so the reader knows the byte order
+		return textunicode.UTF16(textunicode.LittleEndian, textunicode.UseBOM), nil
+	}
+	return htmlindex.Get(canonical)
+}
+
+// Detects the encoding of the data by the byte order mark, by the charset declared in HTML or the encoding
+// declared in XML, and if none of these is found, by checking whether it is valid UTF-8. Otherwise, it guesses
+// which of the legacy encodings for Serbian Latin and Cyrillic gives the most Serbian letters.
+func Detect(data []byte, truncated bool) string {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		return UTF8
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return "utf-16le"
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return "utf-16be"
+	}
+
+	if name := detectUTF16(data); name != "" {
+		return name
+	}
+
+	for _, declaration := range []*regexp.Regexp{declaredXmlEncoding, declaredHtmlCharset} {
+		if match := declaration.FindSubmatch(data); match != nil {
+			if name, err := Name(string(match[1])); err == nil {
+				return name
+			}
+		}
+	}
+
+	if truncated {
+		// the last character could be cut in the middle
+		for i := 1; i < utf8.UTFMax && i <= len(data); i++ {
+			if utf8.RuneStart(data[len(data)-i]) {
+				if !utf8.FullRune(data[len(data)-i:]) {
+					data = data[:len(data)-i]
+				}
+				break
+			}
+		}
+	}
+	if utf8.Valid(data) {
+		return UTF8
+	}
+
+	best, bestScore := UTF8, 0
+	for _, name := range legacyEncodings {
+		enc, _ := htmlindex.Get(name)
+		decoded, err := enc.NewDecoder().Bytes(data)
+		if err != nil {
+			continue
+		}
+		if score := serbianScore(string(decoded)); score > bestScore {
+			best, bestScore = name, score
+		}
+	}
+	return best
+}
+
+// Detects UTF-16 without the byte order mark by the zero bytes, which are the high bytes of the ASCII characters.
+func detectUTF16(data []byte) string {
+	even, odd := 0, 0
+	for i, b := range data {
+		if b != 0 {
+			continue
+		}
+		if i%2 == 0 {
+			even++
+		} else {
+			odd++
+		}
+	}
+	switch {
+	case len(data) < 2:
+		return ""
+	case odd > len(data)/4 && even == 0:
+		return "utf-16le"
+	case even > len(data)/4 && odd == 0:
+		return "utf-16be"
+	}
+	return ""
+}
+
+// Detects the encoding of the beginning of the file.
+func DetectFile(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	sample := make([]byte, SampleSize)
+	n, err := io.ReadFull(file, sample)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	return Detect(sample[:n], n == SampleSize), nil
+}
+
+// Scores the text by the letters of the Serbian alphabets, preferring lower case letters since they are more
+// frequent, and penalizing control characters and letters of the other alphabets.
+func serbianScore(text string) int {
+	score := 0
+	for _, r := range text {
+		lower := unicode.ToLower(r)
+		switch {
+		case strings.ContainsRune(serbianLatinLetters, lower) || strings.ContainsRune(serbianCyrillicLetters, lower):
+			score++
+			if r == lower {
+				score++
+			}
+		case unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t':
+			score -= 5
+		case unicode.IsLetter(r):
+			score--
+		}
+	}
+	return score
+}
+
+// Wraps the reader so that it yields UTF-8 decoded from the named encoding. A byte order mark, if present,
+// is removed, and it overrides the named encoding.
+func NewReader(reader io.Reader, name string) (io.Reader, error) {
+	if canonical, ok := isYuscii(name); ok {
+		return newYusciiReader(reader, canonical), nil
+	}
+	enc, err := lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return transform.NewReader(reader, textunicode.BOMOverride(enc.NewDecoder())), nil
+}
+
+// Wraps the writer so that UTF-8 written to it is encoded to the named encoding. Characters which do not exist
+// in the encoding are written as HTML numeric character references in markup, and are an error otherwise. Closing
+// the returned writer writes out what the encoder still holds, but does not close the wrapped writer.
+func NewWriter(writer io.Writer, name string, markup bool) (io.WriteCloser, error) {
+	if _, ok := isYuscii(name); ok {
+		return nil, messages.Errorf(messages.InputOnlyEncoding, name)
+	}
+	enc, err := lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	if IsUTF8(name) {
+		return nopCloser{writer}, nil
+	}
+	if markup {
+		return transform.NewWriter(writer, encoding.HTMLEscapeUnsupported(enc.NewEncoder())), nil
+	}
+	return &encodeWriter{transform.NewWriter(writer, enc.NewEncoder()), enc, name}, nil
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+// Writer which encodes UTF-8 to an encoding, and names the first character which does not exist in it
+type encodeWriter struct {
+	*transform.Writer
+	enc  encoding.Encoding
+	name string
+}
+
+func (writer *encodeWriter) Write(p []byte) (int, error) {
+	n, err := writer.Writer.Write(p)
+	// the error of an unsupported character suggests its replacement
+	var unsupported interface{ Replacement() byte }
+	if errors.As(err, &unsupported) {
+		for _, r := range string(p) {
+			if _, encodeErr := writer.enc.NewEncoder().String(string(r)); encodeErr != nil {
+				return n, messages.Errorf(messages.UnencodableCharacter, r, writer.name)
+			}
+		}
+	}
+	return n, err
+}
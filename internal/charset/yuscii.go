@@ -0,0 +1,198 @@
+package charset
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+)
+
+const (
+	YusciiLatin    = "yuscii-latin"    // JUS I.B1.002
+	YusciiCyrillic = "yuscii-cyrillic" // JUS I.B1.003
+)
+
+var (
+	yusciiNames = map[string]string{
+		"yuscii-latin":    YusciiLatin,
+		"yuscii-lat":      YusciiLatin,
+		"yuscii":          YusciiLatin,
+		"jus-i.b1.002":    YusciiLatin,
+		"yuscii-cyrillic": YusciiCyrillic,
+		"yuscii-cyr":      YusciiCyrillic,
+		"jus-i.b1.003":    YusciiCyrillic,
+	}
+
+	yusciiSpecials = map[rune]string{
+		'@':  "Ž",
+		'[':  "Š",
+		'\\': "Đ",
+		']':  "Ć",
+		'^':  "Č",
+		'`':  "ž",
+		'{':  "š",
+		'|':  "đ",
+		'}':  "ć",
+		'~':  "č",
+	}
+	yusciiCyrillicSpecials = map[rune]string{
+		'@':  "Ж",
+		'[':  "Ш",
+		'\\': "Ђ",
+		']':  "Ћ",
+		'^':  "Ч",
+		'`':  "ж",
+		'{':  "ш",
+		'|':  "ђ",
+		'}':  "ћ",
+		'~':  "ч",
+	}
+	yusciiCyrillicLetters = map[rune]string{
+		'A': "А", 'B': "Б", 'C': "Ц", 'D': "Д", 'E': "Е", 'F': "Ф", 'G': "Г", 'H': "Х", 'I': "И",
+		'J': "Ј", 'K': "К", 'L': "Л", 'M': "М", 'N': "Н", 'O': "О", 'P': "П", 'Q': "Љ", 'R': "Р",
+		'S': "С", 'T': "Т", 'U': "У", 'V': "В", 'W': "Њ", 'X': "Џ", 'Y': "Ѕ", 'Z': "З",
+		'a': "а", 'b': "б", 'c': "ц", 'd': "д", 'e': "е", 'f': "ф", 'g': "г", 'h': "х", 'i': "и",
+		'j': "ј", 'k': "к", 'l': "л", 'm': "м", 'n': "н", 'o': "о", 'p': "п", 'q': "љ", 'r': "р",
+		's': "с", 't': "т", 'u': "у", 'v': "в", 'w': "њ", 'x': "џ", 'y': "ѕ", 'z': "з",
+	}
+
+	// Words that look like an e-mail address, a web address or a piece of computer code keep their brackets
+	yusciiEmail = regexp.MustCompile(`[A-Za-z0-9._-]@[A-Za-z0-9-]+\.[A-Za-z]{2,}`)
+	yusciiUrl   = regexp.MustCompile(`://|^www\.`)
+	yusciiCode  = regexp.MustCompile("[()=;<>_$#*+&%/]|[0-9][\\[\\]{}@\\\\|^~`]|[\\[\\]{}@\\\\|^~`][0-9]")
+	yusciiSpace = regexp.MustCompile(`\s+`)
+)
+
+// Reads the text in YUSCII and yields UTF-8. Characters which stand for the letters are converted only inside
+// the words, so the brackets standing alone or in a word that looks like code, an e-mail or a web address are
+// preserved, and so is the text between „<|” and „|>” together with these markers.
+type yusciiReader struct {
+	reader    *bufio.Reader
+	cyrillic  bool
+	protected bool // inside the text between the markers, which can span multiple lines
+	pending   []byte
+	err       error
+}
+
+func isYuscii(name string) (string, bool) {
+	canonical, ok := yusciiNames[strings.ToLower(strings.TrimSpace(name))]
+	return canonical, ok
+}
+
+func newYusciiReader(reader io.Reader, name string) io.Reader {
+	return &yusciiReader{reader: bufio.NewReader(reader), cyrillic: name == YusciiCyrillic}
+}
+
+func (r *yusciiReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 && r.err == nil {
+		line, err := r.reader.ReadString('\n')
+		r.pending = []byte(r.decodeLine(line))
+		r.err = err
+	}
+	if len(r.pending) == 0 {
+		return 0, r.err
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+func (r *yusciiReader) decodeLine(line string) string {
+	var sb strings.Builder
+	for line != "" {
+		if r.protected {
+			end := strings.Index(line, "|>")
+			if end < 0 {
+				sb.WriteString(line)
+				break
+			}
+			sb.WriteString(line[:end+2])
+			line = line[end+2:]
+			r.protected = false
+			continue
+		}
+
+		start := strings.Index(line, "<|")
+		if start < 0 {
+			sb.WriteString(r.decodeText(line))
+			break
+		}
+		sb.WriteString(r.decodeText(line[:start]))
+		sb.WriteString("<|")
+		line = line[start+2:]
+		r.protected = true
+	}
+	return sb.String()
+}
+
+// Decodes the words of the text, keeping the whitespace between them as it is.
+func (r *yusciiReader) decodeText(text string) string {
+	var sb strings.Builder
+	for {
+		loc := yusciiSpace.FindStringIndex(text)
+		if loc == nil {
+			sb.WriteString(r.decodeWord(text))
+			break
+		}
+		sb.WriteString(r.decodeWord(text[:loc[0]]))
+		sb.WriteString(text[loc[0]:loc[1]])
+		text = text[loc[1]:]
+	}
+	return sb.String()
+}
+
+// Decodes the runs of letters and characters standing for the letters, but only the runs with at least
+// one letter in them.
+func (r *yusciiReader) decodeWord(word string) string {
+	if yusciiEmail.MatchString(word) || yusciiUrl.MatchString(word) || yusciiCode.MatchString(word) {
+		return word
+	}
+
+	var sb strings.Builder
+	runes := []rune(word)
+	for i := 0; i < len(runes); {
+		if !r.isWordRune(runes[i]) {
+			sb.WriteRune(runes[i])
+			i++
+			continue
+		}
+		end := i
+		hasLetter := false
+		for end < len(runes) && r.isWordRune(runes[end]) {
+			if isAsciiLetter(runes[end]) {
+				hasLetter = true
+			}
+			end++
+		}
+		for _, c := range runes[i:end] {
+			if decoded, ok := r.decode(c); ok && hasLetter {
+				sb.WriteString(decoded)
+			} else {
+				sb.WriteRune(c)
+			}
+		}
+		i = end
+	}
+	return sb.String()
+}
+
+func (r *yusciiReader) isWordRune(c rune) bool {
+	_, special := yusciiSpecials[c]
+	return special || isAsciiLetter(c)
+}
+
+func (r *yusciiReader) decode(c rune) (string, bool) {
+	if !r.cyrillic {
+		decoded, ok := yusciiSpecials[c]
+		return decoded, ok
+	}
+	if decoded, ok := yusciiCyrillicSpecials[c]; ok {
+		return decoded, ok
+	}
+	decoded, ok := yusciiCyrillicLetters[c]
+	return decoded, ok
+}
+
+func isAsciiLetter(c rune) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
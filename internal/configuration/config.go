@@ -1,13 +1,45 @@
 package configuration
 
+// Configurations holds the settings of the configuration file. The settings at the top of the file are used
+// by default, and a profile from Profiles, selected with -profile, changes the ones it gives.
 type Configurations struct {
-	Version      string
-	OutputDir    string
-	C2lPtr       bool
-	L2cPtr       bool
-	HtmlPtr      bool
-	TextPtr      bool
-	InputPathPtr string
+	Version  string
+	Profile  `mapstructure:",squash"`
+	Profiles map[string]Profile
+}
+
+// Profile holds the settings for one kind of material: the direction and the format, the dictionaries and
+// the scheme, the rules for the output and the patterns of the files which are skipped.
+type Profile struct {
+	OutputDir         string
+	C2lPtr            bool
+	L2cPtr            bool
+	C2aPtr            bool
+	SlugPtr           bool
+	HtmlPtr           bool
+	TextPtr           bool
+	InputPathPtr      string
+	ColumnsPtr        string
+	HeaderPtr         bool
+	XliffSourcePtr    bool
+	HtmlAttributesPtr string
+	HtmlMetaPtr       string
+	FragmentPtr       bool
+	NoLangPtr         bool
+	StreamPtr         bool
+	FromEncodingPtr   string
+	ToEncodingPtr     string
+	DiacriticsPtr     bool
+	LexiconPtr        string
+	SchemePtr         string
+	SchemeFilePtr     string
+	ReportPtr         string
+	ExplainPtr        bool
+	InteractivePtr    bool
+	UserDictPtr       string
+	FailFastPtr       bool
+	SkipPtr           string
+	UiLangPtr         string
 }
 
 // SomeConfigurations exported
@@ -1,51 +1,202 @@
 package configuration
 
 import (
+	"errors"
 	"flag"
-	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/eevan78/translit/internal/dictionary"
+	"github.com/eevan78/translit/internal/messages"
 	"github.com/eevan78/translit/internal/terminal"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
+const (
+	projectConfigFile = ".translit.yaml" // in the directory the program is run from
+	configFile        = "config.yaml"    // in the translit directory of the user and of the system
+	envPrefix         = "TRANSLIT_"
+)
+
 var configuration Configurations
 
-func ConfigInit() {
-	if *dictionary.ConfigPtr {
-		readConfig()
-		initVars()
-		initFlags()
+// Groups of the flags of which only one is set, so that a flag from the command line replaces the one from
+// the configuration. The format is not given for an input file, whose format is detected.
+var exclusiveFlags = [][]string{{"l2c", "c2l", "c2a", "slug"}, {"html", "text", "i"}}
+
+// Reads the configuration when it is requested with -c, -config or -profile, and the TRANSLIT_ environment
+// variables on every run, and sets the flags from them. The given flags, which come from the command line by their
+// names, keep their values. Returns an error when the configuration cannot be read.
+func ConfigInit(given map[string]flag.Value) error {
+	fileRequested := *dictionary.ConfigPtr || *dictionary.ConfigFilePtr != "" || *dictionary.ProfilePtr != ""
+	if !fileRequested && !envGiven() {
+		return nil
+	}
+	values := map[string]string{}
+	for name, value := range given {
+		values[name] = value.String()
+	}
+
+	if err := readConfig(fileRequested); err != nil {
+		return err
+	}
+	initVars()
+	initFlags()
+
+	for _, group := range exclusiveFlags {
+		if slices.ContainsFunc(group, func(name string) bool { return given[name] != nil }) {
+			for _, name := range group {
+				option := flag.Lookup(name)
+				option.Value.Set(option.DefValue)
+			}
+		}
 	}
+	for name, value := range given {
+		value.Set(values[name])
+	}
+	return nil
 }
 
-func readConfig() {
-	viper.SetConfigName("config")
-	viper.AddConfigPath("../../configs/")
-	viper.SetConfigType("yaml")
-	viper.AutomaticEnv()
+// Reads the settings of the configuration file, when it is requested, and of the environment variables.
+func readConfig(fileRequested bool) error {
+	// the settings of an earlier reading are forgotten
+	viper.Reset()
+	defaultVars()
+	bindEnv()
+
+	path := ""
+	if fileRequested {
+		var err error
+		if path, err = ConfigPath(); err != nil {
+			return err
+		}
+		version, err := Validate(path)
+		if err != nil {
+			return err
+		}
+		viper.SetConfigFile(path)
+		viper.SetConfigType("yaml")
 
-	if err := viper.ReadInConfig(); err != nil {
-		fmt.Printf("Грешка при читању конфигурационог фајла, %s", err)
+		if err := viper.ReadInConfig(); err != nil {
+			return messages.Errorf(messages.ConfigReadError, path, err)
+		}
+		if version != CurrentVersion {
+			messages.Fprintf(os.Stderr, messages.ConfigMigrated, path, version, CurrentVersion)
+			migrate(version)
+		}
+		if err := selectProfile(path); err != nil {
+			return err
+		}
 	}
 
-	err := viper.Unmarshal(&configuration)
-	if err != nil {
-		fmt.Printf("Грешка при раду са конфигурационим фајлом, %v", err)
+	if err := viper.Unmarshal(&configuration); err != nil && path == "" {
+		return messages.Errorf(messages.ConfigEnvError, err)
+	} else if err != nil {
+		return messages.Errorf(messages.ConfigError, path, err)
 	}
+	return nil
 }
 
-func initVars() {
-	defaultVars()
+// Merges the settings of the profile selected with -profile over the settings at the top of the file, so that
+// the environment variables still override them. Like a flag, a setting of the profile which is true replaces
+// the settings of its exclusive group.
+func selectProfile(path string) error {
+	name := *dictionary.ProfilePtr
+	if name == "" {
+		return nil
+	}
+	key := "profiles." + name
+	if !viper.IsSet(key) {
+		names := []string{}
+		for profile := range viper.GetStringMap("profiles") {
+			names = append(names, profile)
+		}
+		sort.Strings(names)
+		return messages.Errorf(messages.UnknownProfile, name, path, strings.Join(names, ", "))
+	}
+	settings := viper.GetStringMap(key)
+	for _, group := range exclusiveSettings {
+		if slices.ContainsFunc(group, func(name string) bool { return settings[strings.ToLower(name)] == true }) {
+			for _, name := range group {
+				if _, ok := settings[strings.ToLower(name)]; !ok {
+					settings[strings.ToLower(name)] = false
+				}
+			}
+		}
+	}
+	return viper.MergeConfigMap(settings)
+}
+
+// Returns the configuration file given with -config, which has to exist, or else the first one found in the
+// search paths.
+func ConfigPath() (string, error) {
+	if path := *dictionary.ConfigFilePtr; path != "" {
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return "", messages.Errorf(messages.ConfigMissing, path)
+		} else if err != nil {
+			return "", messages.Errorf(messages.ConfigReadError, path, err)
+		}
+		return path, nil
+	}
+
+	paths := searchPaths()
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", messages.Errorf(messages.ConfigNotFound, strings.Join(paths, ", "))
+}
+
+// Returns the paths the configuration file is searched for in, in the order of precedence: the project, the user
+// and the system.
+func searchPaths() []string {
+	paths := []string{projectConfigFile}
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			configHome = filepath.Join(home, ".config")
+		}
+	}
+	if configHome != "" {
+		paths = append(paths, filepath.Join(configHome, "translit", configFile))
+	}
+	return append(paths, filepath.Join("/etc", "translit", configFile))
+}
+
+// Reports whether any of the TRANSLIT_ environment variables is set.
+func envGiven() bool {
+	return slices.ContainsFunc(os.Environ(), func(variable string) bool { return strings.HasPrefix(variable, envPrefix) })
+}
 
+// Lets the environment variables override the settings of the configuration file. The variable is named by the
+// setting in capitals, without the Ptr suffix, like TRANSLIT_SCHEME for SchemePtr.
+func bindEnv() {
+	for _, field := range reflect.VisibleFields(reflect.TypeOf(configuration)) {
+		// the profiles are selected with -profile, and their settings are the same as the ones at the top
+		if field.Anonymous || field.Type.Kind() == reflect.Map {
+			continue
+		}
+		viper.BindEnv(field.Name, envPrefix+strings.ToUpper(strings.TrimSuffix(field.Name, "Ptr")))
+	}
+}
+
+func initVars() {
 	terminal.OutputDir = viper.GetString("OutputDir")
 	dictionary.ConfigVersion = viper.GetString("Version")
 }
 
 func defaultVars() {
-	viper.SetDefault("outputDir", "../../output")
-	viper.SetDefault("version", "v0.3.0")
+	viper.SetDefault("outputDir", terminal.OutputDir)
+	viper.SetDefault("version", oldestVersion)
+	viper.SetDefault("HtmlAttributesPtr", *dictionary.HtmlAttributesPtr)
+	viper.SetDefault("HtmlMetaPtr", *dictionary.HtmlMetaPtr)
+	viper.SetDefault("SchemePtr", *dictionary.SchemePtr)
 }
 
 func initFlags() {
@@ -53,7 +204,30 @@ func initFlags() {
 	viper.BindPFlags(pflag.CommandLine)
 	*dictionary.C2lPtr = configuration.C2lPtr
 	*dictionary.L2cPtr = configuration.L2cPtr
+	*dictionary.C2aPtr = configuration.C2aPtr
+	*dictionary.SlugPtr = configuration.SlugPtr
 	*dictionary.HtmlPtr = configuration.HtmlPtr
 	*dictionary.TextPtr = configuration.TextPtr
 	*dictionary.InputPathPtr = configuration.InputPathPtr
+	*dictionary.ColumnsPtr = configuration.ColumnsPtr
+	*dictionary.HeaderPtr = configuration.HeaderPtr
+	*dictionary.XliffSourcePtr = configuration.XliffSourcePtr
+	*dictionary.HtmlAttributesPtr = configuration.HtmlAttributesPtr
+	*dictionary.HtmlMetaPtr = configuration.HtmlMetaPtr
+	*dictionary.FragmentPtr = configuration.FragmentPtr
+	*dictionary.NoLangPtr = configuration.NoLangPtr
+	*dictionary.StreamPtr = configuration.StreamPtr
+	*dictionary.FromEncodingPtr = configuration.FromEncodingPtr
+	*dictionary.ToEncodingPtr = configuration.ToEncodingPtr
+	*dictionary.DiacriticsPtr = configuration.DiacriticsPtr
+	*dictionary.LexiconPtr = configuration.LexiconPtr
+	*dictionary.SchemePtr = configuration.SchemePtr
+	*dictionary.SchemeFilePtr = configuration.SchemeFilePtr
+	*dictionary.ReportPtr = configuration.ReportPtr
+	*dictionary.ExplainPtr = configuration.ExplainPtr
+	*dictionary.InteractivePtr = configuration.InteractivePtr
+	*dictionary.UserDictPtr = configuration.UserDictPtr
+	*dictionary.FailFastPtr = configuration.FailFastPtr
+	*dictionary.SkipPtr = configuration.SkipPtr
+	*dictionary.UiLangPtr = configuration.UiLangPtr
 }
@@ -0,0 +1,218 @@
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/eevan78/translit/internal/messages"
+	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
+)
+
+// Version of the configuration file which is read without migration
+const CurrentVersion = "v0.4.0"
+
+// Version of the files written before the version was checked, which is also assumed when it is not given
+const oldestVersion = "v0.3.0"
+
+// migration changes the settings read from a file of the version to the next version.
+type migration struct {
+	version string
+	migrate func()
+}
+
+// Migrations of the older files, in the order of the versions
+var migrations = []migration{
+	// before the configuration was searched for, the output went next to the source tree
+	{"v0.3.0", func() {
+		if !viper.InConfig("outputdir") {
+			viper.MergeConfigMap(map[string]any{"outputDir": "../../output"})
+		}
+	}},
+}
+
+// Groups of the settings of which only one may be true at the same place in the file
+var exclusiveSettings = [][]string{{"L2cPtr", "C2lPtr", "C2aPtr", "SlugPtr"}, {"HtmlPtr", "TextPtr"}}
+
+// Returns the versions of the configuration file the program reads.
+func versions() []string {
+	versions := []string{}
+	for _, migration := range migrations {
+		versions = append(versions, migration.version)
+	}
+	return append(versions, CurrentVersion)
+}
+
+// Checks the configuration file: the names, the types and the combinations of the settings, and the version.
+// Returns the version of the file, and the errors with the line of every wrong setting.
+func Validate(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", messages.Errorf(messages.ConfigReadError, path, err)
+	}
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return "", messages.Errorf(messages.ConfigReadError, path, err)
+	}
+	// an empty file has no settings
+	if len(document.Content) == 0 {
+		return oldestVersion, nil
+	}
+
+	validator := validator{path: path, version: oldestVersion}
+	validator.mapping(document.Content[0], filepath.Base(path), true)
+	slices.SortStableFunc(validator.errs, func(a, b lineError) int { return a.line - b.line })
+	errs := make([]error, len(validator.errs))
+	for i, err := range validator.errs {
+		errs[i] = err.err
+	}
+	return validator.version, errors.Join(errs...)
+}
+
+// validator collects the errors of the configuration file.
+type validator struct {
+	path    string
+	version string
+	errs    []lineError
+}
+
+// lineError is an error of the setting on the line of the file.
+type lineError struct {
+	line int
+	err  error
+}
+
+func (validator *validator) fail(node *yaml.Node, key messages.Key, args ...any) {
+	err := fmt.Errorf("%s:%d: %s", validator.path, node.Line, messages.Sprintf(key, args...))
+	validator.errs = append(validator.errs, lineError{node.Line, err})
+}
+
+// Checks the settings of the mapping with the name, which is the top of the file or a profile.
+func (validator *validator) mapping(node *yaml.Node, name string, top bool) {
+	if node.Kind != yaml.MappingNode {
+		validator.fail(node, messages.ConfigNotMapping, name)
+		return
+	}
+	set := map[string]*yaml.Node{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch setting := strings.ToLower(key.Value); {
+		case top && setting == "version":
+			validator.versionValue(value)
+		case top && setting == "profiles":
+			validator.profiles(value)
+		default:
+			field, ok := settingField(setting)
+			if !ok {
+				validator.fail(key, messages.ConfigUnknownKey, key.Value)
+				continue
+			}
+			if validator.value(key, value, field) {
+				set[field.Name] = key
+			}
+		}
+	}
+
+	for _, group := range exclusiveSettings {
+		var names []string
+		for _, name := range group {
+			if set[name] != nil {
+				names = append(names, name)
+			}
+		}
+		if len(names) > 1 {
+			validator.fail(set[names[1]], messages.ConfigConflict, names[0], names[1])
+		}
+	}
+}
+
+// Checks that the value has the type of the setting. Returns whether the setting is true. Only true and false are
+// taken as a bool, because the values like yes and on, which YAML 1.1 allows, are not read as a bool when
+// the configuration is loaded.
+func (validator *validator) value(key *yaml.Node, value *yaml.Node, field reflect.StructField) bool {
+	if field.Type.Kind() == reflect.Bool {
+		if value.Kind != yaml.ScalarNode || value.ShortTag() != "!!bool" || (value.Value != "true" && value.Value != "false") {
+			validator.fail(value, messages.ConfigNotBool, key.Value)
+			return false
+		}
+		return value.Value == "true"
+	}
+	if value.Kind != yaml.ScalarNode {
+		validator.fail(value, messages.ConfigNotString, key.Value)
+	}
+	return false
+}
+
+func (validator *validator) versionValue(value *yaml.Node) {
+	if value.Kind != yaml.ScalarNode || !slices.Contains(versions(), value.Value) {
+		validator.fail(value, messages.ConfigUnknownVersion, value.Value, strings.Join(versions(), ", "))
+		return
+	}
+	validator.version = value.Value
+}
+
+func (validator *validator) profiles(value *yaml.Node) {
+	if value.Kind != yaml.MappingNode {
+		validator.fail(value, messages.ConfigNotMapping, "profiles")
+		return
+	}
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		validator.mapping(value.Content[i+1], "profiles."+value.Content[i].Value, false)
+	}
+}
+
+// Returns the field of the profile for the setting, regardless of the case of its name.
+func settingField(name string) (reflect.StructField, bool) {
+	for _, field := range reflect.VisibleFields(reflect.TypeOf(Profile{})) {
+		if strings.EqualFold(field.Name, name) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// Brings the settings read from a file of an older version to the current version.
+func migrate(version string) {
+	start := slices.IndexFunc(migrations, func(migration migration) bool { return migration.version == version })
+	if start < 0 {
+		return
+	}
+	for _, migration := range migrations[start:] {
+		migration.migrate()
+	}
+	viper.MergeConfigMap(map[string]any{"version": CurrentVersion})
+}
+
+// Writes the settings which are used, after the profile, the migration and the environment variables are applied
+// to the file, in the form of the current version.
+func Show(writer io.Writer) error {
+	settings := &yaml.Node{Kind: yaml.MappingNode}
+	add := func(name string, value any) error {
+		valueNode := &yaml.Node{}
+		if err := valueNode.Encode(value); err != nil {
+			return err
+		}
+		settings.Content = append(settings.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, valueNode)
+		return nil
+	}
+
+	if err := add("version", configuration.Version); err != nil {
+		return err
+	}
+	profile := reflect.ValueOf(configuration.Profile)
+	for i, field := range reflect.VisibleFields(profile.Type()) {
+		if err := add(field.Name, profile.Field(i).Interface()); err != nil {
+			return err
+		}
+	}
+
+	encoder := yaml.NewEncoder(writer)
+	defer encoder.Close()
+	return encoder.Encode(settings)
+}
@@ -0,0 +1,206 @@
+// Package diacritic restores the diacritics of Serbian Latin text typed without them (ošišana latinica),
+// like cevapcici for ćevapčići, using the frequencies of words and of pairs of adjacent words from a lexicon.
+package diacritic
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/eevan78/translit/internal/messages"
+)
+
+// How many times the most frequent form has to be more frequent than the next one to be chosen without context
+const dominance = 20
+
+var (
+	//go:embed lexicon.txt
+	defaultLexicon string
+
+	stripper     = strings.NewReplacer("č", "c", "ć", "c", "š", "s", "ž", "z", "đ", "dj")
+	letters      = regexp.MustCompile(`\p{L}+`)
+	sentenceEnds = ".!?…"
+)
+
+// Lexicon holds the known forms of words and the frequencies of words and of pairs of adjacent words.
+type Lexicon struct {
+	forms   map[string][]form // by the word without diacritics
+	bigrams map[string]int    // by the two words separated with a space
+}
+
+type form struct {
+	word      string
+	frequency int
+}
+
+// Ambiguity is a word left as it is because more of its forms are possible.
+type Ambiguity struct {
+	Word       string
+	Candidates []string
+}
+
+// Restorer restores the diacritics word by word, remembering the previous word in the sentence as the context.
+type Restorer struct {
+	lexicon  *Lexicon
+	previous string
+}
+
+// Loads the lexicon from the file, or the built-in lexicon if the path is empty.
+func Load(filePath string) (*Lexicon, error) {
+	if filePath == "" {
+		return Parse(strings.NewReader(defaultLexicon))
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Parse(file)
+}
+
+// Parses the lexicon, which has an entry per line: a word and its frequency, or two adjacent words and
+// the frequency of the pair. Empty lines and lines starting with # are skipped. Words without diacritics
+// are listed too when they are words of their own, so that they are not changed.
+func Parse(reader io.Reader) (*Lexicon, error) {
+	lexicon := &Lexicon{forms: map[string][]form{}, bigrams: map[string]int{}}
+	scanner := bufio.NewScanner(reader)
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		fields := strings.Fields(strings.ToLower(entry))
+		if len(fields) != 2 && len(fields) != 3 {
+			return nil, messages.Errorf(messages.LexiconWordExpected, line)
+		}
+		frequency, err := strconv.Atoi(fields[len(fields)-1])
+		if err != nil || frequency <= 0 {
+			return nil, messages.Errorf(messages.LexiconBadFrequency, line, fields[len(fields)-1])
+		}
+		if len(fields) == 3 {
+			lexicon.bigrams[fields[0]+" "+fields[1]] += frequency
+			continue
+		}
+		lexicon.addForm(fields[0], frequency)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, forms := range lexicon.forms {
+		sort.SliceStable(forms, func(i, j int) bool { return forms[i].frequency > forms[j].frequency })
+	}
+	return lexicon, nil
+}
+
+// Adds the frequency of the word, summing it up when the word is listed more than once.
+func (lexicon *Lexicon) addForm(word string, frequency int) {
+	key := Strip(word)
+	for i := range lexicon.forms[key] {
+		if lexicon.forms[key][i].word == word {
+			lexicon.forms[key][i].frequency += frequency
+			return
+		}
+	}
+	lexicon.forms[key] = append(lexicon.forms[key], form{word: word, frequency: frequency})
+}
+
+// Returns the text with the diacritics removed, the way it is typed without them.
+func Strip(text string) string {
+	return stripper.Replace(text)
+}
+
+func NewRestorer(lexicon *Lexicon) *Restorer {
+	return &Restorer{lexicon: lexicon}
+}
+
+// Forgets the context, e.g. at the beginning of a new document.
+func (r *Restorer) Reset() {
+	r.previous = ""
+}
+
+// Restores the diacritics of the words in the token, which can contain punctuation and hyphens. A word is
+// changed only when it has one known form, when the previous word decides between its forms, or when one of
+// the forms is far more frequent than the others. The words left as they are because of more possible forms
+// are returned.
+func (r *Restorer) Restore(token string) (string, []Ambiguity) {
+	var ambiguities []Ambiguity
+	restored := letters.ReplaceAllStringFunc(token, func(word string) string {
+		result, candidates := r.restoreWord(word)
+		if len(candidates) > 0 {
+			ambiguities = append(ambiguities, Ambiguity{Word: word, Candidates: candidates})
+		}
+		r.previous = strings.ToLower(result)
+		return result
+	})
+
+	if loc := letters.FindAllStringIndex(token, -1); len(loc) > 0 {
+		if strings.ContainsAny(token[loc[len(loc)-1][1]:], sentenceEnds) {
+			r.previous = ""
+		}
+	}
+	return restored, ambiguities
+}
+
+func (r *Restorer) restoreWord(word string) (string, []string) {
+	lower := strings.ToLower(word)
+	if Strip(lower) != lower {
+		// already typed with diacritics
+		return word, nil
+	}
+	forms := r.lexicon.forms[lower]
+	switch {
+	case len(forms) == 0:
+		return word, nil
+	case len(forms) == 1:
+		return applyCase(word, forms[0].word), nil
+	}
+
+	if r.previous != "" {
+		best, bestCount, tie := "", 0, false
+		for _, form := range forms {
+			count := r.lexicon.bigrams[r.previous+" "+form.word]
+			if count > bestCount {
+				best, bestCount, tie = form.word, count, false
+			} else if count == bestCount && count > 0 {
+				tie = true
+			}
+		}
+		if bestCount > 0 && !tie {
+			return applyCase(word, best), nil
+		}
+	}
+
+	if forms[0].frequency >= dominance*forms[1].frequency {
+		return applyCase(word, forms[0].word), nil
+	}
+
+	candidates := make([]string, len(forms))
+	for i, form := range forms {
+		candidates[i] = applyCase(word, form.word)
+	}
+	return word, candidates
+}
+
+// Gives the restored form the case of the typed word: all upper case, capitalized or all lower case.
+// Words with any other mix of cases are left as they were typed.
+func applyCase(typed string, restored string) string {
+	first, size := utf8.DecodeRuneInString(typed)
+	switch {
+	case typed == strings.ToLower(typed):
+		return restored
+	case typed == strings.ToUpper(typed) && utf8.RuneCountInString(typed) > 1:
+		return strings.ToUpper(restored)
+	case unicode.IsUpper(first) && typed[size:] == strings.ToLower(typed[size:]):
+		restoredFirst, restoredSize := utf8.DecodeRuneInString(restored)
+		return string(unicode.ToUpper(restoredFirst)) + restored[restoredSize:]
+	}
+	return typed
+}
@@ -1,7 +1,6 @@
 package dictionary
 
 import (
-	"flag"
 	"regexp"
 	"strings"
 
@@ -9,25 +8,64 @@ import (
 )
 
 var (
-	Whitepref   = regexp.MustCompile(`^[\s\p{Zs}]+`)
-	Whitesuff   = regexp.MustCompile(`[\s\p{Zs}]+$`)
-	Pref        = regexp.MustCompile(`^[('“”"‘…]+`)
-	Suff        = regexp.MustCompile(`[)'“"‘…,!?\.]+$`)
-	Prefmap     = strings.NewReplacer("(", "(", "'", "’", "“", "„", "”", "„", "\"", "„", "‘", "’", "…", "…")
-	Suffmap     = strings.NewReplacer(")", ")", "'", "’", "“", "”", "\"", "”", "‘", "’", "…", "…", "!", "!", ",", ",", "?", "?", ".", ".")
-	Fixdigraphs = regexp.MustCompile(`\p{Lu}*(Dž|Nj|Lj)\p{Lu}+(Dž|Nj|Lj)?\p{Lu}*`)
+	Whitepref  = regexp.MustCompile(`^[\s\p{Zs}]+`)
+	Whitesuff  = regexp.MustCompile(`[\s\p{Zs}]+$`)
+	Whitespace = regexp.MustCompile(`[\s\p{Zs}]+`)
+	Pref       = regexp.MustCompile(`^[('“”"‘…]+`)
+	Suff       = regexp.MustCompile(`[)'“"‘…,!?\.]+$`)
+	Prefmap    = strings.NewReplacer("(", "(", "'", "’", "“", "„", "”", "„", "\"", "„", "‘", "’", "…", "…")
+	Suffmap    = strings.NewReplacer(")", ")", "'", "’", "“", "”", "\"", "”", "‘", "’", "…", "…", "!", "!", ",", ",", "?", "?", ".", ".")
 
 	ConfigVersion  string
 	ProgramVersion = "0.4.0"
 
-	L2cPtr       = flag.Bool("l2c", false, "`Смер` пресловљавања је латиница у ћирилицу")
-	C2lPtr       = flag.Bool("c2l", false, "`Смер` пресловљавања је ћирилица у латиницу")
-	HtmlPtr      = flag.Bool("html", false, "`Формат` улаза је (X)HTML")
-	TextPtr      = flag.Bool("text", false, "`Формат` улаза је прости текст")
-	ConfigPtr    = flag.Bool("c", false, "Користи се конфигурација")
-	InputPathPtr = flag.String("i", "", "Путања улазног фајла или директоријума")
+	// Settings of the program, which its flags are bound to. Without the flags, as in the library, they keep
+	// these values.
+	L2cPtr            = new(bool)
+	C2lPtr            = new(bool)
+	C2aPtr            = new(bool)
+	SlugPtr           = new(bool)
+	HtmlPtr           = new(bool)
+	TextPtr           = new(bool)
+	ConfigPtr         = new(bool)
+	ConfigFilePtr     = new(string)
+	ProfilePtr        = new(string)
+	InputPathPtr      = new(string)
+	ColumnsPtr        = new(string)
+	HeaderPtr         = new(bool)
+	HtmlAttributesPtr = setting("title,alt,placeholder,aria-label,aria-description,value")
+	HtmlMetaPtr       = setting("description,keywords,og:title,og:description,og:site_name,twitter:title,twitter:description")
+	FragmentPtr       = new(bool)
+	NoLangPtr         = new(bool)
+	StreamPtr         = new(bool)
+	FromEncodingPtr   = new(string)
+	ToEncodingPtr     = new(string)
+	DiacriticsPtr     = new(bool)
+	LexiconPtr        = new(string)
+	SchemePtr         = setting("sr")
+	SchemeFilePtr     = new(string)
+	DumpSchemePtr     = new(string)
+	ReportPtr         = new(string)
+	ExplainPtr        = new(bool)
+	InteractivePtr    = new(bool)
+	UserDictPtr       = new(string)
+	FailFastPtr       = new(bool)
+	SkipPtr           = new(string)
+	XliffSourcePtr    = new(bool)
+	UiLangPtr         = new(string)
 
-	Tbl = trie.BuildFromMap(map[string]string{
+	// Folding of the Latin text to ASCII, with đ written as dj, as it is usual in Serbian
+	AsciiTbl = strings.NewReplacer(
+		"Č", "C", "Ć", "C", "Š", "S", "Ž", "Z", "Đ", "Dj",
+		"č", "c", "ć", "c", "š", "s", "ž", "z", "đ", "dj",
+		"Ǆ", "DZ", "ǅ", "Dz", "ǆ", "dz", "Ǉ", "LJ", "ǈ", "Lj", "ǉ", "lj", "Ǌ", "NJ", "ǋ", "Nj", "ǌ", "nj",
+		"ß", "ss", "Æ", "AE", "æ", "ae", "Œ", "OE", "œ", "oe", "Ø", "O", "ø", "o", "Ł", "L", "ł", "l",
+		"„", "\"", "“", "\"", "”", "\"", "«", "\"", "»", "\"", "‘", "'", "’", "'", "‚", "'",
+		"…", "...", "–", "-", "—", "-", "\u00A0", " ",
+	)
+
+	// Serbian Latin to Cyrillic, with the lookalike letters, ligatures and decomposed letters
+	L2cMap = map[string]string{
 		"A":   "А",
 		"B":   "Б",
 		"V":   "В",
@@ -131,7 +169,9 @@ var (
 		"dž": "џ", // d + z with caron
 		"š":   "ш",
 		"š":  "ш", // s with caron
-	})
+	}
+	Tbl = trie.BuildFromMap(L2cMap)
+	// Serbian Cyrillic to Latin
 	Tbl1 = map[string]string{
 		"А": "A",
 		"Б": "B",
@@ -723,3 +763,7 @@ var (
 		},
 	}
 )
+
+func setting(value string) *string {
+	return &value
+}
@@ -0,0 +1,210 @@
+package dictionary
+
+// Standardized romanizations of the Cyrillic script, which are used only for the transliteration to the Latin
+// script. Besides the Serbian letters, they have the letters of the other Cyrillic alphabets, so that the names
+// in them are romanized too.
+var (
+	// ISO 9:1995, a letter for a letter, so the transliteration can be reversed
+	iso9C2l = map[string]string{
+		"А": "A",
+		"Б": "B",
+		"В": "V",
+		"Г": "G",
+		"Ґ": "G̀",
+		"Д": "D",
+		"Ѓ": "Ǵ",
+		"Ђ": "Đ",
+		"Е": "E",
+		"Ё": "Ë",
+		"Є": "Ê",
+		"Ж": "Ž",
+		"З": "Z",
+		"Ѕ": "Ẑ",
+		"И": "I",
+		"І": "Ì",
+		"Ї": "Ï",
+		"Й": "J",
+		"Ј": "J̌",
+		"К": "K",
+		"Л": "L",
+		"Љ": "L̂",
+		"М": "M",
+		"Н": "N",
+		"Њ": "N̂",
+		"О": "O",
+		"П": "P",
+		"Р": "R",
+		"С": "S",
+		"Т": "T",
+		"Ќ": "Ḱ",
+		"Ћ": "Ć",
+		"У": "U",
+		"Ў": "Ǔ",
+		"Ф": "F",
+		"Х": "H",
+		"Ц": "C",
+		"Ч": "Č",
+		"Џ": "D̂",
+		"Ш": "Š",
+		"Щ": "Ŝ",
+		"Ъ": "ʺ",
+		"Ы": "Y",
+		"Ь": "ʹ",
+		"Э": "È",
+		"Ю": "Û",
+		"Я": "Â",
+		"а": "a",
+		"б": "b",
+		"в": "v",
+		"г": "g",
+		"ґ": "g̀",
+		"д": "d",
+		"ѓ": "ǵ",
+		"ђ": "đ",
+		"е": "e",
+		"ё": "ë",
+		"є": "ê",
+		"ж": "ž",
+		"з": "z",
+		"ѕ": "ẑ",
+		"и": "i",
+		"і": "ì",
+		"ї": "ï",
+		"й": "j",
+		"ј": "ǰ",
+		"к": "k",
+		"л": "l",
+		"љ": "l̂",
+		"м": "m",
+		"н": "n",
+		"њ": "n̂",
+		"о": "o",
+		"п": "p",
+		"р": "r",
+		"с": "s",
+		"т": "t",
+		"ќ": "ḱ",
+		"ћ": "ć",
+		"у": "u",
+		"ў": "ǔ",
+		"ф": "f",
+		"х": "h",
+		"ц": "c",
+		"ч": "č",
+		"џ": "d̂",
+		"ш": "š",
+		"щ": "ŝ",
+		"ъ": "ʺ",
+		"ы": "y",
+		"ь": "ʹ",
+		"э": "è",
+		"ю": "û",
+		"я": "â",
+	}
+	// ICAO Doc 9303, for the machine readable travel documents, only in the letters of the English alphabet
+	icaoC2l = map[string]string{
+		"А": "A",
+		"Б": "B",
+		"В": "V",
+		"Г": "G",
+		"Ґ": "G",
+		"Д": "D",
+		"Ђ": "D",
+		"Ѓ": "G",
+		"Е": "E",
+		"Ё": "E",
+		"Є": "Ie",
+		"Ж": "Zh",
+		"З": "Z",
+		"Ѕ": "Dz",
+		"И": "I",
+		"І": "I",
+		"Ї": "I",
+		"Й": "I",
+		"Ј": "J",
+		"К": "K",
+		"Л": "L",
+		"Љ": "Lj",
+		"М": "M",
+		"Н": "N",
+		"Њ": "Nj",
+		"О": "O",
+		"П": "P",
+		"Р": "R",
+		"С": "S",
+		"Т": "T",
+		"Ћ": "C",
+		"Ќ": "K",
+		"У": "U",
+		"Ў": "U",
+		"Ф": "F",
+		"Х": "Kh",
+		"Ц": "Ts",
+		"Ч": "Ch",
+		"Џ": "Dz",
+		"Ш": "Sh",
+		"Щ": "Shch",
+		"Ъ": "Ie",
+		"Ы": "Y",
+		"Ь": "",
+		"Э": "E",
+		"Ю": "Iu",
+		"Я": "Ia",
+		"а": "a",
+		"б": "b",
+		"в": "v",
+		"г": "g",
+		"ґ": "g",
+		"д": "d",
+		"ђ": "d",
+		"ѓ": "g",
+		"е": "e",
+		"ё": "e",
+		"є": "ie",
+		"ж": "zh",
+		"з": "z",
+		"ѕ": "dz",
+		"и": "i",
+		"і": "i",
+		"ї": "i",
+		"й": "i",
+		"ј": "j",
+		"к": "k",
+		"л": "l",
+		"љ": "lj",
+		"м": "m",
+		"н": "n",
+		"њ": "nj",
+		"о": "o",
+		"п": "p",
+		"р": "r",
+		"с": "s",
+		"т": "t",
+		"ћ": "c",
+		"ќ": "k",
+		"у": "u",
+		"ў": "u",
+		"ф": "f",
+		"х": "kh",
+		"ц": "ts",
+		"ч": "ch",
+		"џ": "dz",
+		"ш": "sh",
+		"щ": "shch",
+		"ъ": "ie",
+		"ы": "y",
+		"ь": "",
+		"э": "e",
+		"ю": "iu",
+		"я": "ia",
+	}
+	// BGN/PCGN uses the Serbian Latin alphabet, and Đ, Ć and Dz for the Macedonian letters
+	bgnC2l = extendMap(Tbl1, map[string]string{
+		"Ѓ": "Đ",
+		"ѓ": "đ",
+		"Ќ": "Ć",
+		"ќ": "ć",
+		"Ѕ": "Dz",
+		"ѕ": "dz",
+	})
+)
@@ -0,0 +1,190 @@
+package dictionary
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/porfirion/trie"
+)
+
+// Scheme maps a Latin alphabet to a Cyrillic one and back. The mappings are tries, so that a letter can be written
+// with more characters, like a digraph or a letter with a combining accent, and the longest match wins.
+type Scheme struct {
+	Name      string
+	Languages []string           // primary language subtags of the text in the scheme, the first one is used in language tags
+	Mechanism string             // transformation mechanism recorded in the language tags, if it is a standard one
+	L2c       *trie.Trie[string] // nil for the schemes used only for the transliteration to the Latin script
+	C2l       *trie.Trie[string]
+	Digraphs  []string // Latin digraphs of the single Cyrillic letters, like Lj for Љ, written in capitals next to capitals
+
+	// Beginnings of the words where the Latin letters of a digraph are two letters, by the digraph,
+	// and how they are split
+	DigraphExceptions   map[string][]string
+	DigraphReplacements map[string]map[string]string
+}
+
+var (
+	// Montenegrin Latin has Ś and Ź, and Montenegrin Cyrillic has С́ and З́, written with the combining acute accent
+	montenegrinL2c = map[string]string{
+		"Ś":  "С́",
+		"Ś": "С́", // S with acute accent
+		"ś":  "с́",
+		"ś": "с́", // s with acute accent
+		"Ź":  "З́",
+		"Ź": "З́", // Z with acute accent
+		"ź":  "з́",
+		"ź": "з́", // z with acute accent
+	}
+	montenegrinC2l = map[string]string{
+		"С́": "Ś",
+		"с́": "ś",
+		"З́": "Ź",
+		"з́": "ź",
+	}
+
+	// Macedonian has Ѓ, Ќ and Ѕ instead of Ђ and Ћ, written as Gj, Kj and Dz in Latin, or as Ǵ and Ḱ
+	macedonianL2c = map[string]string{
+		"GJ": "Ѓ",
+		"Gj": "Ѓ",
+		"gj": "ѓ",
+		"Ǵ":  "Ѓ",
+		"Ǵ": "Ѓ", // G with acute accent
+		"ǵ":  "ѓ",
+		"ǵ": "ѓ", // g with acute accent
+		"KJ": "Ќ",
+		"Kj": "Ќ",
+		"kj": "ќ",
+		"Ḱ":  "Ќ",
+		"Ḱ": "Ќ", // K with acute accent
+		"ḱ":  "ќ",
+		"ḱ": "ќ", // k with acute accent
+		"DZ": "Ѕ",
+		"Dz": "Ѕ",
+		"dz": "ѕ",
+		"Ǳ":  "Ѕ",
+		"ǲ":  "Ѕ",
+		"ǳ":  "ѕ",
+	}
+	macedonianC2l = map[string]string{
+		"Ѓ": "Gj",
+		"ѓ": "gj",
+		"Ќ": "Kj",
+		"ќ": "kj",
+		"Ѕ": "Dz",
+		"ѕ": "dz",
+	}
+	macedonianDigraphExceptions = map[string][]string{
+		"dz": {
+			"nadz",
+			"odz",
+			"podz",
+			"predz",
+		},
+		"nj": {
+			"injek",
+			"konjunk",
+		},
+	}
+	macedonianDigraphReplacements = map[string]map[string]string{
+		"dz": {
+			"dz": "d\u200Cz",
+			"Dz": "D\u200Cz",
+			"DZ": "D\u200CZ",
+		},
+		"nj": DigraphReplacements["nj"],
+	}
+
+	serbianDigraphs    = []string{"Dž", "Nj", "Lj"}
+	macedonianDigraphs = []string{"Dž", "Nj", "Lj", "Gj", "Kj", "Dz"}
+	icaoDigraphs       = []string{"Shch", "Zh", "Kh", "Ts", "Ch", "Sh", "Ie", "Iu", "Ia", "Dz", "Lj", "Nj"}
+
+	Schemes = map[string]*Scheme{
+		"sr": {
+			Name:                "sr",
+			Languages:           []string{"sr"},
+			L2c:                 Tbl,
+			C2l:                 trie.BuildFromMap(Tbl1),
+			Digraphs:            serbianDigraphs,
+			DigraphExceptions:   DigraphExceptions,
+			DigraphReplacements: DigraphReplacements,
+		},
+		"cnr": {
+			Name:                "cnr",
+			Languages:           []string{"cnr", "sr"},
+			L2c:                 trie.BuildFromMap(extendMap(L2cMap, montenegrinL2c)),
+			C2l:                 trie.BuildFromMap(extendMap(Tbl1, montenegrinC2l)),
+			Digraphs:            serbianDigraphs,
+			DigraphExceptions:   DigraphExceptions,
+			DigraphReplacements: DigraphReplacements,
+		},
+		"mk": {
+			Name:                "mk",
+			Languages:           []string{"mk"},
+			L2c:                 trie.BuildFromMap(extendMap(withoutValues(L2cMap, "Ђ", "ђ", "Ћ", "ћ"), macedonianL2c)),
+			C2l:                 trie.BuildFromMap(extendMap(withoutValues(Tbl1, "Đ", "đ", "Ć", "ć"), macedonianC2l)),
+			Digraphs:            macedonianDigraphs,
+			DigraphExceptions:   macedonianDigraphExceptions,
+			DigraphReplacements: macedonianDigraphReplacements,
+		},
+		"iso9": {
+			Name:      "iso9",
+			Languages: []string{"sr"},
+			Mechanism: "iso",
+			C2l:       trie.BuildFromMap(iso9C2l),
+		},
+		"bgn": {
+			Name:      "bgn",
+			Languages: []string{"sr"},
+			Mechanism: "bgn",
+			C2l:       trie.BuildFromMap(bgnC2l),
+			Digraphs:  serbianDigraphs,
+		},
+		"icao": {
+			Name:      "icao",
+			Languages: []string{"sr"},
+			C2l:       trie.BuildFromMap(icaoC2l),
+			Digraphs:  icaoDigraphs,
+		},
+	}
+)
+
+// Returns the scheme selected by the flag, or the Serbian one if the name is not known.
+func CurrentScheme() *Scheme {
+	if scheme, ok := Schemes[*SchemePtr]; ok {
+		return scheme
+	}
+	return Schemes["sr"]
+}
+
+// Returns the sorted names of the schemes.
+func SchemeNames() []string {
+	names := []string{}
+	for name := range Schemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Returns a new map with the entries of the base map, except those with the given values.
+func withoutValues(base map[string]string, values ...string) map[string]string {
+	result := make(map[string]string, len(base))
+	for key, value := range base {
+		if !slices.Contains(values, value) {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// Returns a new map with the entries of the base map and the extension, where the extension wins.
+func extendMap(base map[string]string, extension map[string]string) map[string]string {
+	result := make(map[string]string, len(base)+len(extension))
+	for key, value := range base {
+		result[key] = value
+	}
+	for key, value := range extension {
+		result[key] = value
+	}
+	return result
+}
@@ -0,0 +1,240 @@
+package dictionary
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/eevan78/translit/internal/messages"
+	"github.com/porfirion/trie"
+	"go.yaml.in/yaml/v3"
+)
+
+// SchemeDefinition is a scheme as written in a YAML or JSON file. The keys of the mappings can be sequences of
+// more letters, and the longest one matching the text wins. With autocase, the mappings written in lower case
+// are completed with their capitalized and upper case forms, like Lj and LJ for lj.
+type SchemeDefinition struct {
+	Name      string                  `yaml:"name" json:"name"`
+	Languages []string                `yaml:"languages" json:"languages"`
+	Mechanism string                  `yaml:"mechanism,omitempty" json:"mechanism,omitempty"`
+	Autocase  bool                    `yaml:"autocase,omitempty" json:"autocase,omitempty"`
+	L2c       map[string]string       `yaml:"l2c,omitempty" json:"l2c,omitempty"`
+	C2l       map[string]string       `yaml:"c2l" json:"c2l"`
+	Digraphs  []string                `yaml:"digraphs,omitempty" json:"digraphs,omitempty"`
+	Splits    map[string]DigraphSplit `yaml:"splits,omitempty" json:"splits,omitempty"`
+}
+
+// DigraphSplit lists the beginnings of the words where the Latin letters of a digraph are two separate letters,
+// and how the digraph is written in them, usually with the zero width non-joiner between the letters.
+type DigraphSplit struct {
+	Exceptions   []string          `yaml:"exceptions" json:"exceptions"`
+	Replacements map[string]string `yaml:"replacements" json:"replacements"`
+}
+
+// Loads the scheme from a YAML or JSON file, validates it and adds it to the schemes. Returns its name.
+func LoadScheme(filePath string) (string, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	definition := SchemeDefinition{}
+	if strings.EqualFold(filepath.Ext(filePath), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&definition)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&definition)
+	}
+	if err != nil {
+		return "", messages.Errorf(messages.InvalidScheme, err)
+	}
+
+	scheme, err := definition.build()
+	if err != nil {
+		return "", err
+	}
+	Schemes[scheme.Name] = scheme
+	return scheme.Name, nil
+}
+
+// Validates the definition and builds the scheme from it.
+func (definition *SchemeDefinition) build() (*Scheme, error) {
+	if definition.Name == "" {
+		return nil, messages.Errorf(messages.SchemeWithoutName)
+	}
+	if _, ok := Schemes[definition.Name]; ok {
+		return nil, messages.Errorf(messages.SchemeExists, definition.Name)
+	}
+	if len(definition.Languages) == 0 {
+		return nil, messages.Errorf(messages.SchemeWithoutLanguage, definition.Name)
+	}
+	if len(definition.C2l) == 0 {
+		return nil, messages.Errorf(messages.SchemeWithoutC2l, definition.Name)
+	}
+
+	l2c, err := completeMapping(definition.L2c, definition.Autocase, "l2c")
+	if err != nil {
+		return nil, err
+	}
+	c2l, err := completeMapping(definition.C2l, definition.Autocase, "c2l")
+	if err != nil {
+		return nil, err
+	}
+
+	latin := map[string]bool{}
+	for _, value := range c2l {
+		latin[value] = true
+	}
+	for _, digraph := range definition.Digraphs {
+		if !latin[digraph] {
+			return nil, messages.Errorf(messages.DigraphNotInC2l, digraph)
+		}
+	}
+	for digraph, split := range definition.Splits {
+		if _, ok := l2c[digraph]; !ok {
+			return nil, messages.Errorf(messages.DigraphNotInL2c, digraph)
+		}
+		if len(split.Exceptions) == 0 || len(split.Replacements) == 0 {
+			return nil, messages.Errorf(messages.DigraphWithoutSplits, digraph)
+		}
+		for key, replacement := range split.Replacements {
+			if !strings.EqualFold(key, digraph) || key == replacement {
+				return nil, messages.Errorf(messages.InvalidSplit, key, replacement, digraph)
+			}
+		}
+	}
+
+	scheme := &Scheme{
+		Name:      definition.Name,
+		Languages: definition.Languages,
+		Mechanism: definition.Mechanism,
+		C2l:       trie.BuildFromMap(c2l),
+		Digraphs:  definition.Digraphs,
+	}
+	if len(l2c) > 0 {
+		scheme.L2c = trie.BuildFromMap(l2c)
+	}
+	if len(definition.Splits) > 0 {
+		scheme.DigraphExceptions = map[string][]string{}
+		scheme.DigraphReplacements = map[string]map[string]string{}
+		for digraph, split := range definition.Splits {
+			scheme.DigraphExceptions[digraph] = split.Exceptions
+			scheme.DigraphReplacements[digraph] = split.Replacements
+		}
+	}
+	return scheme, nil
+}
+
+// Checks the mapping and, with autocase, adds the capitalized and upper case forms of the entries written in lower
+// case. The forms written in the file win over the added ones, but two added forms must not be in conflict.
+func completeMapping(mapping map[string]string, autocase bool, section string) (map[string]string, error) {
+	result := make(map[string]string, len(mapping))
+	for key, value := range mapping {
+		if key == "" {
+			return nil, messages.Errorf(messages.EmptyKey, section)
+		}
+		if value == "" && section == "l2c" {
+			return nil, messages.Errorf(messages.EmptyValue, key, section)
+		}
+		result[key] = value
+	}
+	if !autocase {
+		return result, nil
+	}
+
+	added := map[string]string{}
+	for _, key := range sortedKeys(mapping) {
+		value := mapping[key]
+		if key != strings.ToLower(key) {
+			continue
+		}
+		forms := [][2]string{{capitalize(key), capitalize(value)}}
+		if upper := strings.ToUpper(key); upper != forms[0][0] {
+			// a single letter in upper case is the capitalized one, and the words in capitals are left to digraphs
+			forms = append(forms, [2]string{upper, strings.ToUpper(value)})
+		}
+		for _, form := range forms {
+			if _, ok := mapping[form[0]]; ok {
+				continue
+			}
+			if previous, ok := added[form[0]]; ok && previous != form[1] {
+				return nil, messages.Errorf(messages.SchemeConflict, section, form[0], previous, form[1])
+			}
+			added[form[0]] = form[1]
+			result[form[0]] = form[1]
+		}
+	}
+	return result, nil
+}
+
+func capitalize(s string) string {
+	first, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(first)) + s[size:]
+}
+
+func sortedKeys(mapping map[string]string) []string {
+	keys := make([]string, 0, len(mapping))
+	for key := range mapping {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Writes the scheme in the format of the scheme files, yaml or json.
+func DumpScheme(writer io.Writer, scheme *Scheme, format string) error {
+	definition := SchemeDefinition{
+		Name:      scheme.Name,
+		Languages: scheme.Languages,
+		Mechanism: scheme.Mechanism,
+		L2c:       trieToMap(scheme.L2c),
+		C2l:       trieToMap(scheme.C2l),
+		Digraphs:  scheme.Digraphs,
+	}
+	if len(scheme.DigraphExceptions) > 0 {
+		definition.Splits = map[string]DigraphSplit{}
+		for digraph, exceptions := range scheme.DigraphExceptions {
+			definition.Splits[digraph] = DigraphSplit{
+				Exceptions:   slices.Sorted(slices.Values(exceptions)),
+				Replacements: scheme.DigraphReplacements[digraph],
+			}
+		}
+	}
+
+	switch format {
+	case "yaml":
+		encoder := yaml.NewEncoder(writer)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(definition); err != nil {
+			return err
+		}
+		return encoder.Close()
+	case "json":
+		encoder := json.NewEncoder(writer)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(definition)
+	}
+	return messages.Errorf(messages.UnknownSchemeFormat, format)
+}
+
+func trieToMap(mapping *trie.Trie[string]) map[string]string {
+	if mapping == nil {
+		return nil
+	}
+	result := map[string]string{}
+	mapping.Iterate(func(prefix []byte, value string) {
+		result[string(prefix)] = value
+	})
+	return result
+}
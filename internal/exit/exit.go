@@ -7,21 +7,70 @@ import (
 	"time"
 
 	"github.com/eevan78/translit/internal/dictionary"
+	"github.com/eevan78/translit/internal/messages"
 )
 
 func Pomoc() {
-	fmt.Fprintf(flag.CommandLine.Output(), "Ово је филтер %s верзија %s\nСаставио eevan78, 2024-%v\n\n", os.Args[0], dictionary.ProgramVersion, (time.Now()).Year())
-	fmt.Fprintf(flag.CommandLine.Output(), "Филтер чита UTF-8 кодирани текст са стандардног улаза или из наведеног фајла и исписује га на\nстандардни излаз или у излазни фајл, пресловљен сагласно са следећим заставицама:\n")
+	Localize()
+	output := flag.CommandLine.Output()
+	messages.Fprintf(output, messages.HelpHeader, os.Args[0], dictionary.ProgramVersion, (time.Now()).Year())
+	messages.Fprintf(output, messages.HelpIntro)
 	flag.PrintDefaults()
-	fmt.Fprintf(flag.CommandLine.Output(), "\nКада се наведе -c, не сме да се наведе ниједна друга заставица. Програм се подешава читањем\nконфигурације. У супротном, мора да се наведе по једна и само једна заставица из обе групе\nСмер и Формат. Када се наведе заставица за улазни фајл потребно је да се наведе само заставица смера.\nЦеле речи између „<|” и „|>” у простом тексту се не пресловљавају.\nТекст унутар <span lang=\"sr-Latn\"></span> елемента у (X)HTML се не пресловљава у ћирилицу,\nа текст унутар <span lang=\"sr-Cyrl\"></span> се не пресловљава у латиницу.\n\nПримери:\n%s -l2c -html\t\tпреслови (X)HTML у ћирилицу\n%s -text -c2l\t\tпреслови прости текст у латиницу\n%s -c\t\t\tпрограм чита подешавања из фајла конфигурације\n", os.Args[0], os.Args[0], os.Args[0])
+	messages.Fprintf(output, messages.HelpDetails, os.Args[0])
+	messages.Fprintf(output, messages.HelpCommands, os.Args[0])
 }
 
-func ExitWithError(err error, filename string) {
-	fmt.Fprintln(os.Stderr, "Грешка у раду са: ", filename, err)
-	os.Exit(1)
+// Selects the language of the messages from the flag, or from the environment, and translates the help of
+// the flags to it. An unknown language in the flag leaves the language from the environment.
+func Localize() error {
+	err := messages.Select(*dictionary.UiLangPtr)
+	if err != nil {
+		messages.Select("")
+	}
+	flag.VisitAll(func(f *flag.Flag) {
+		if usage := messages.FlagUsage(f.Name); usage != "" {
+			f.Usage = usage
+		}
+	})
+	return err
 }
 
-func ExitWithHelp() {
-	Pomoc()
-	os.Exit(1)
+// Exit codes of the program
+const (
+	Success          = 0
+	Failure          = 1 // nothing is transliterated
+	Usage            = 2 // the flags are used the wrong way
+	PartialFailure   = 3 // some of the files are transliterated, and some are not
+	Untransliterated = 4 // the checked text is not transliterated yet
+)
+
+// ErrUsage is the error of the flags used the wrong way, after which the help is shown.
+var ErrUsage error = usageError{}
+
+type usageError struct{}
+
+// The message is in the language selected when it is shown, not when the error is made.
+func (usageError) Error() string {
+	return messages.Text(messages.UsageError)
+}
+
+// Reports the error on the standard error, with the file it is about, if there is one.
+func PrintError(err error, filename string) {
+	if filename == "" {
+		messages.Fprintf(os.Stderr, messages.Error, err)
+		return
+	}
+	messages.Fprintf(os.Stderr, messages.ErrorWithFile, filename, err)
+}
+
+// Shows what is wrong with the flags and where to find the help, or the whole help when it is not known what is
+// wrong. Returns the exit code for the wrong usage.
+func PrintUsage(err error) int {
+	if err == nil || err == ErrUsage {
+		Pomoc()
+		return Usage
+	}
+	fmt.Fprintln(os.Stderr, err)
+	messages.Fprintf(os.Stderr, messages.HelpHint, os.Args[0])
+	return Usage
 }
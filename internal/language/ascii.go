@@ -0,0 +1,59 @@
+package language
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/eevan78/translit/internal/dictionary"
+	"golang.org/x/text/unicode/norm"
+)
+
+var slugSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Transliterates the word to the Latin script and folds it to ASCII the Serbian way, so đ becomes dj and not d.
+// A word written in all capitals stays in all capitals, e.g. ĐORĐE becomes DJORDJE, and so does a single capital
+// letter in a sentence written in capitals.
+func c2a(s string, scheme *dictionary.Scheme, capitals bool) string {
+	latin := c2l(s, scheme, capitals)
+	ascii := foldToAscii(latin)
+	if isUppercaseWord(latin) || (capitals && latin == strings.ToUpper(latin)) {
+		return strings.ToUpper(ascii)
+	}
+	return ascii
+}
+
+// Replaces the letters with diacritics and the typographic punctuation with their ASCII counterparts. Letters
+// of other languages lose their diacritical marks.
+func foldToAscii(s string) string {
+	s = dictionary.AsciiTbl.Replace(s)
+	var sb strings.Builder
+	for _, runeValue := range norm.NFD.String(s) {
+		if !unicode.Is(unicode.Mn, runeValue) {
+			sb.WriteRune(runeValue)
+		}
+	}
+	return sb.String()
+}
+
+// Reports whether the word has at least two letters and all of them are capitals.
+func isUppercaseWord(s string) bool {
+	letters := 0
+	for _, runeValue := range s {
+		if unicode.IsLower(runeValue) {
+			return false
+		}
+		if unicode.IsLetter(runeValue) {
+			letters++
+		}
+	}
+	return letters > 1
+}
+
+// Makes a slug for web addresses and file names from the text in either script of the scheme: lower case ASCII
+// letters and digits, with words separated by single hyphens, and without punctuation.
+func Slug(text string, scheme *dictionary.Scheme) string {
+	ascii := strings.ToLower(TransliterateText(text, ToASCII, scheme))
+	ascii = strings.NewReplacer("'", "", "\"", "").Replace(ascii)
+	return strings.Trim(slugSeparators.ReplaceAllString(ascii, "-"), "-")
+}
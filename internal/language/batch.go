@@ -0,0 +1,61 @@
+package language
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/eevan78/translit/internal/dictionary"
+	"github.com/eevan78/translit/internal/exit"
+	"github.com/eevan78/translit/internal/messages"
+)
+
+// Failure is a file which could not be transliterated.
+type Failure struct {
+	Path string
+	Err  error
+}
+
+// Summary of a batch of documents: how many are transliterated, and which could not be.
+type Summary struct {
+	Transliterated int
+	Failures       []Failure
+}
+
+// Records the failure of the file and reports it on the standard error right away.
+func (summary *Summary) fail(path string, err error) {
+	exit.PrintError(err, path)
+	summary.Failures = append(summary.Failures, Failure{Path: path, Err: err})
+}
+
+// Adds the other summary to this one.
+func (summary *Summary) Add(other Summary) {
+	summary.Transliterated += other.Transliterated
+	summary.Failures = append(summary.Failures, other.Failures...)
+}
+
+// Reports whether the batch has to stop, which is after the first failure with -fail-fast.
+func (summary *Summary) Stopped() bool {
+	return *dictionary.FailFastPtr && len(summary.Failures) > 0
+}
+
+// Returns the exit code of the program for the batch.
+func (summary *Summary) ExitCode() int {
+	switch {
+	case len(summary.Failures) == 0:
+		return exit.Success
+	case summary.Transliterated == 0:
+		return exit.Failure
+	}
+	return exit.PartialFailure
+}
+
+// Lists the files which could not be transliterated on the standard error, if there are any.
+func (summary *Summary) PrintFailures() {
+	if len(summary.Failures) == 0 {
+		return
+	}
+	messages.Fprintf(os.Stderr, messages.FailureSummary, len(summary.Failures), len(summary.Failures)+summary.Transliterated)
+	for _, failure := range summary.Failures {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", failure.Path, failure.Err)
+	}
+}
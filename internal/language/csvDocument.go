@@ -0,0 +1,306 @@
+package language
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/eevan78/translit/internal/dictionary"
+	"github.com/eevan78/translit/internal/messages"
+	"github.com/eevan78/translit/internal/terminal"
+)
+
+const (
+	csvSampleSize  = 64 * 1024 // how much of the input is inspected to detect the delimiter and the quote character
+	csvSampleLines = 20
+)
+
+var csvDelimiters = []rune{',', ';', '\t', '|'}
+
+type CsvDocument struct {
+	inputFilePath  string
+	outputFilePath string
+	fop            *terminal.FileOperator
+	tsv            bool // tab separated values, so the delimiter is not detected
+}
+
+type csvField struct {
+	value  string
+	quoted bool // field was quoted in the input, so it stays quoted in the output
+}
+
+type csvRecord struct {
+	fields  []csvField
+	lineEnd string // line ending as found in the input, empty for the last line without one
+}
+
+// Reads RFC 4180 records with an arbitrary delimiter and quote character. Unlike encoding/csv, it keeps the
+// information about quoting and line endings, so the output differs from the input only in transliterated fields.
+type csvReader struct {
+	reader    *bufio.Reader
+	delimiter rune
+	quote     rune
+	line      int
+}
+
+func (document *CsvDocument) open() error {
+	document.fop = &terminal.FileOperator{}
+	return document.fop.OpenAndCreate(document.inputFilePath, document.outputFilePath)
+}
+
+func (document *CsvDocument) transliterate() error {
+	reader := bufio.NewReaderSize(document.fop.Reader, csvSampleSize)
+	sample, err := reader.Peek(csvSampleSize)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return err
+	}
+
+	delimiter, quote := detectCsvDialect(string(sample), len(sample) == csvSampleSize, document.tsv)
+	csvReader := &csvReader{reader: reader, delimiter: delimiter, quote: quote, line: 1}
+	columns, names := parseCsvColumns(*dictionary.ColumnsPtr)
+	hasHeader := *dictionary.HeaderPtr || len(names) > 0
+
+	for row := 0; ; row++ {
+		record, err := csvReader.read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if row == 0 && hasHeader {
+			resolveCsvColumnNames(record, names, columns, document.getInputFilePath())
+		} else {
+			for i := range record.fields {
+				if (len(columns) == 0 && len(names) == 0) || columns[i] {
+					record.fields[i].value = transliterateText(record.fields[i].value)
+				}
+			}
+		}
+
+		if err := writeCsvRecord(document.fop.Writer, record, delimiter, quote); err != nil {
+			return err
+		}
+	}
+
+	return document.fop.Writer.Flush()
+}
+
+func (document *CsvDocument) getInputFilePath() string {
+	return document.inputFilePath
+}
+
+func (document *CsvDocument) getOuputFilePath() string {
+	return document.outputFilePath
+}
+
+func (document *CsvDocument) finalize() error {
+	if err := document.fop.Close(); err != nil {
+		return err
+	}
+	messages.Printf(messages.Success, document.inputFilePath, document.outputFilePath)
+	return nil
+}
+
+func (document *CsvDocument) abort() {
+	document.fop.Abort()
+}
+
+func (r *csvReader) read() (*csvRecord, error) {
+	record := &csvRecord{}
+	var field strings.Builder
+	quoted := false
+	inQuotes := false
+	atFieldStart := true
+
+	endField := func() {
+		record.fields = append(record.fields, csvField{value: field.String(), quoted: quoted})
+		field.Reset()
+		quoted = false
+		atFieldStart = true
+	}
+
+	for {
+		c, _, err := r.reader.ReadRune()
+		if err == io.EOF {
+			if inQuotes {
+				return nil, messages.Errorf(messages.UnterminatedQuote, r.line)
+			}
+			if len(record.fields) == 0 && atFieldStart && !quoted {
+				return nil, io.EOF
+			}
+			endField()
+			return record, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case inQuotes:
+			if c == r.quote {
+				// doubled quote character inside a quoted field stands for the quote itself
+				if next, _, err := r.reader.ReadRune(); err == nil {
+					if next == r.quote {
+						field.WriteRune(c)
+						continue
+					}
+					_ = r.reader.UnreadRune()
+				}
+				inQuotes = false
+				continue
+			}
+			if c == '\n' {
+				r.line++
+			}
+			field.WriteRune(c)
+		case c == r.quote && atFieldStart:
+			quoted, inQuotes = true, true
+			atFieldStart = false
+		case c == r.delimiter:
+			endField()
+		case c == '\r':
+			if next, _, err := r.reader.ReadRune(); err == nil {
+				if next == '\n' {
+					record.lineEnd = "\r\n"
+					endField()
+					r.line++
+					return record, nil
+				}
+				_ = r.reader.UnreadRune()
+			}
+			field.WriteRune(c)
+			atFieldStart = false
+		case c == '\n':
+			record.lineEnd = "\n"
+			endField()
+			r.line++
+			return record, nil
+		default:
+			field.WriteRune(c)
+			atFieldStart = false
+		}
+	}
+}
+
+// Writes the record quoting the fields as RFC 4180 requires, with the quote character of the input. Fields that
+// were quoted in the input stay quoted.
+func writeCsvRecord(writer *bufio.Writer, record *csvRecord, delimiter rune, quote rune) error {
+	special := string(delimiter) + string(quote) + "\r\n"
+	for i, field := range record.fields {
+		if i > 0 {
+			if _, err := writer.WriteRune(delimiter); err != nil {
+				return err
+			}
+		}
+		value := field.value
+		if field.quoted || strings.ContainsAny(value, special) {
+			value = string(quote) + strings.ReplaceAll(value, string(quote), string(quote)+string(quote)) + string(quote)
+		}
+		if _, err := writer.WriteString(value); err != nil {
+			return err
+		}
+	}
+	_, err := writer.WriteString(record.lineEnd)
+	return err
+}
+
+// Detects the delimiter as the candidate that occurs most times in every sampled line, and the quote character
+// as the one that encloses more fields. Defaults are comma and double quote.
+func detectCsvDialect(sample string, truncated bool, tsv bool) (delimiter rune, quote rune) {
+	lines := strings.Split(sample, "\n")
+	if truncated && len(lines) > 1 {
+		// the last line is probably cut in the middle
+		lines = lines[:len(lines)-1]
+	}
+	sampled := []string{}
+	for _, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+		if line == "" {
+			continue
+		}
+		sampled = append(sampled, line)
+		if len(sampled) == csvSampleLines {
+			break
+		}
+	}
+
+	delimiter = ','
+	if tsv {
+		delimiter = '\t'
+	} else if len(sampled) > 0 {
+		best := 0
+		for _, candidate := range csvDelimiters {
+			least := -1
+			for _, line := range sampled {
+				count := strings.Count(line, string(candidate))
+				if least < 0 || count < least {
+					least = count
+				}
+			}
+			if least > best {
+				best = least
+				delimiter = candidate
+			}
+		}
+	}
+
+	double, single := 0, 0
+	for _, line := range sampled {
+		for _, field := range strings.Split(line, string(delimiter)) {
+			field = strings.TrimSpace(field)
+			if len(field) < 2 {
+				continue
+			}
+			if strings.HasPrefix(field, "\"") && strings.HasSuffix(field, "\"") {
+				double++
+			} else if strings.HasPrefix(field, "'") && strings.HasSuffix(field, "'") {
+				single++
+			}
+		}
+	}
+	quote = '"'
+	if single > double {
+		quote = '\''
+	}
+
+	return delimiter, quote
+}
+
+// Parses the column selection given as a comma separated list. Numbers are 1-based column indexes, and everything
+// else are column names which are looked up in the header.
+func parseCsvColumns(selection string) (columns map[int]bool, names []string) {
+	columns = map[int]bool{}
+	if strings.TrimSpace(selection) == "" {
+		return columns, names
+	}
+	for _, column := range strings.Split(selection, ",") {
+		column = strings.TrimSpace(column)
+		if column == "" {
+			continue
+		}
+		if index, err := strconv.Atoi(column); err == nil && index > 0 {
+			columns[index-1] = true
+		} else {
+			names = append(names, column)
+		}
+	}
+	return columns, names
+}
+
+func resolveCsvColumnNames(header *csvRecord, names []string, columns map[int]bool, filePath string) {
+	for _, name := range names {
+		found := false
+		for i, field := range header.fields {
+			if strings.EqualFold(strings.TrimSpace(field.value), name) {
+				columns[i] = true
+				found = true
+			}
+		}
+		if !found {
+			messages.Printf(messages.MissingColumn, name, filePath)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package language
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/eevan78/translit/internal/diacritic"
+	"github.com/eevan78/translit/internal/dictionary"
+	"github.com/eevan78/translit/internal/messages"
+)
+
+var diacriticRestorer *diacritic.Restorer
+
+func loadLexicon() error {
+	lexicon, err := diacritic.Load(*dictionary.LexiconPtr)
+	if err != nil {
+		return fmt.Errorf("%s: %w", *dictionary.LexiconPtr, err)
+	}
+	diacriticRestorer = diacritic.NewRestorer(lexicon)
+	return nil
+}
+
+// Restores the diacritics of the word typed without them. Words with more possible forms are left as they are
+// and reported on the standard error, so that they can be checked by hand. The word is left as it is when
+// the lexicon is not loaded.
+func restoreDiacritics(word string) string {
+	if diacriticRestorer == nil {
+		return word
+	}
+	restored, ambiguities := diacriticRestorer.Restore(word)
+	for _, ambiguity := range ambiguities {
+		messages.Fprintf(os.Stderr, messages.AmbiguousWord, ambiguity.Word, strings.Join(ambiguity.Candidates, ", "))
+	}
+	return restored
+}
@@ -0,0 +1,49 @@
+package language
+
+import "github.com/eevan78/translit/internal/dictionary"
+
+// Direction of transliteration
+type Direction int
+
+const (
+	NoDirection Direction = iota
+	LatinToCyrillic
+	CyrillicToLatin
+	ToASCII // Cyrillic or Latin to Latin without diacritics
+)
+
+// Returns the name of the direction used in the flags.
+func (direction Direction) String() string {
+	switch direction {
+	case LatinToCyrillic:
+		return "l2c"
+	case CyrillicToLatin:
+		return "c2l"
+	case ToASCII:
+		return "c2a"
+	}
+	return ""
+}
+
+// Returns the direction of transliteration selected by the flags.
+func selectedDirection() Direction {
+	switch {
+	case *dictionary.L2cPtr:
+		return LatinToCyrillic
+	case *dictionary.C2lPtr:
+		return CyrillicToLatin
+	case *dictionary.C2aPtr:
+		return ToASCII
+	}
+	return NoDirection
+}
+
+// Returns the direction with the name used in the flags, like l2c.
+func ParseDirection(name string) (Direction, bool) {
+	for _, direction := range []Direction{LatinToCyrillic, CyrillicToLatin, ToASCII} {
+		if direction.String() == name {
+			return direction, true
+		}
+	}
+	return NoDirection, false
+}
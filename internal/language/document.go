@@ -1,9 +1,12 @@
 package language
 
+// Document is a file, or the standard input, transliterated from the input to the output. After a failure
+// the document is aborted, which closes its files and removes the incomplete output.
 type Document interface {
-	open()
-	transliterate()
+	open() error
+	transliterate() error
 	getInputFilePath() string
 	getOuputFilePath() string
-	finalize()
+	finalize() error
+	abort()
 }
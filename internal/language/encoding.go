@@ -0,0 +1,64 @@
+package language
+
+import (
+	"io"
+	"regexp"
+
+	"github.com/beevik/etree"
+	"github.com/eevan78/translit/internal/charset"
+	"github.com/eevan78/translit/internal/terminal"
+	"golang.org/x/net/html"
+)
+
+var (
+	declaredCharset     = regexp.MustCompile(`(?i)(charset\s*=\s*["']?\s*)([\w:.-]+)`)
+	declaredXmlEncoding = regexp.MustCompile(`(encoding\s*=\s*["'])([\w:.-]+)(["'])`)
+)
+
+// The input is already decoded to UTF-8 when the XML is read, so the declared encoding is ignored.
+func xmlCharsetReader(label string, input io.Reader) (io.Reader, error) {
+	return input, nil
+}
+
+// Sets the charset declared by the meta element to the encoding of the output.
+func adjustHtmlCharset(n *html.Node) {
+	if n.Data != "meta" {
+		return
+	}
+	for i, attrib := range n.Attr {
+		switch attrib.Key {
+		case "charset":
+			if !charset.Same(attrib.Val, terminal.OutputEncoding()) {
+				n.Attr[i].Val = terminal.OutputEncoding()
+			}
+		case "content":
+			n.Attr[i].Val = replaceDeclaredCharset(attrib.Val)
+		}
+	}
+}
+
+// Sets the charset declared in the meta start tag, as found in the markup, to the encoding of the output.
+func replaceDeclaredCharset(text string) string {
+	return declaredCharset.ReplaceAllStringFunc(text, func(declaration string) string {
+		match := declaredCharset.FindStringSubmatch(declaration)
+		if charset.Same(match[2], terminal.OutputEncoding()) {
+			return declaration
+		}
+		return match[1] + terminal.OutputEncoding()
+	})
+}
+
+// Sets the encoding in the XML declaration to the encoding of the output.
+func adjustXmlDeclaration(document *etree.Document) {
+	for _, token := range document.Child {
+		if procInst, ok := token.(*etree.ProcInst); ok && procInst.Target == "xml" {
+			procInst.Inst = declaredXmlEncoding.ReplaceAllStringFunc(procInst.Inst, func(declaration string) string {
+				match := declaredXmlEncoding.FindStringSubmatch(declaration)
+				if charset.Same(match[2], terminal.OutputEncoding()) {
+					return declaration
+				}
+				return match[1] + terminal.OutputEncoding() + match[3]
+			})
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package language
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eevan78/translit/internal/dictionary"
+	"github.com/eevan78/translit/internal/messages"
+)
+
+// Rules which decide how a word is transliterated to the Cyrillic script
+const (
+	transliterated                     = "transliterated"
+	serbianWordWithForeignCombinations = "serbian_word_with_foreign_character_combinations"
+	foreignCharacterCombinations       = "foreign_character_combinations"
+	commonForeignWords                 = "common_foreign_words"
+	wholeForeignWords                  = "whole_foreign_words"
+	foreignPrefix                      = "foreign_prefix"
+	measurementUnit                    = "unit"
+	digraphException                   = "digraph_exception"
+)
+
+var ruleDescriptions = map[string]messages.Key{
+	transliterated:                     messages.RuleTransliterated,
+	serbianWordWithForeignCombinations: messages.RuleSerbianWordWithForeignCombinations,
+	foreignCharacterCombinations:       messages.RuleForeignCharacterCombinations,
+	commonForeignWords:                 messages.RuleCommonForeignWords,
+	wholeForeignWords:                  messages.RuleWholeForeignWords,
+	foreignPrefix:                      messages.RuleForeignPrefix,
+	measurementUnit:                    messages.RuleUnit,
+	digraphException:                   messages.RuleDigraphException,
+}
+
+// Explanation tells how a word is transliterated and which rule decided it. Match is the entry of the list
+// which matched the word, like a prefix of the common foreign words, or the beginnings of the words whose digraphs
+// are split. Split is the word with the zero width non-joiner inserted between the letters of a split digraph.
+type Explanation struct {
+	Word   string
+	Result string
+	Rule   string
+	Match  string
+	Split  string
+}
+
+// Reports whether the transliteration changes the letters of the word, and not only its punctuation, like
+// the quotes and the ellipsis.
+func (explanation Explanation) ChangesLetters() bool {
+	return fixPunctuation(explanation.Result) != fixPunctuation(explanation.Word)
+}
+
+// Describes the decision on a single line, with the zero width non-joiner shown as [ZWNJ].
+func (explanation Explanation) String() string {
+	description := messages.Text(ruleDescriptions[explanation.Rule])
+	if strings.Contains(description, "%s") {
+		description = fmt.Sprintf(description, explanation.Match)
+	}
+	if explanation.Split != "" {
+		description += messages.Sprintf(messages.SplitDigraph, strings.ReplaceAll(explanation.Split, "\u200C", "[ZWNJ]"))
+	}
+	return fmt.Sprintf("%s → %s: %s", explanation.Word, explanation.Result, description)
+}
+
+// Explains how the word is transliterated in the given direction with the scheme. The rules about foreign words
+// and digraphs apply only to the transliteration to the Cyrillic script.
+func explainWord(word string, direction Direction, scheme *dictionary.Scheme, capitals bool) Explanation {
+	explanation := Explanation{
+		Word:   word,
+		Result: transliterateWordTo(word, direction, scheme, capitals),
+		Rule:   transliterated,
+	}
+	if direction != LatinToCyrillic || scheme.L2c == nil {
+		return explanation
+	}
+
+	transliteratedPart := word
+	if index := transliterationIndexOfWordStartsWith(strings.ToLower(word), dictionary.WholeForeignWords, "-"); index >= 0 {
+		explanation.Rule, explanation.Match = foreignPrefix, strings.ToLower(word[:index-1])
+		transliteratedPart = word[index:]
+	} else if rule, match := foreignWordRule(word); rule != "" {
+		explanation.Rule, explanation.Match = rule, match
+		if rule != serbianWordWithForeignCombinations {
+			return explanation
+		}
+	}
+
+	if split, exceptions := splitDigraphsByExceptions(transliteratedPart, scheme); len(exceptions) > 0 {
+		if explanation.Rule == transliterated {
+			explanation.Rule, explanation.Match = digraphException, strings.Join(exceptions, ", ")
+		}
+		explanation.Split = word[:len(word)-len(transliteratedPart)] + split
+	}
+	return explanation
+}
+
+// Explains how every word of the text is transliterated in the given direction with the scheme.
+func ExplainText(text string, direction Direction, scheme *dictionary.Scheme) []Explanation {
+	explanations := []Explanation{}
+	mapWords(text, func(word string, capitals bool) string {
+		explanation := explainWord(word, direction, scheme, capitals)
+		explanations = append(explanations, explanation)
+		return explanation.Result
+	})
+	return explanations
+}
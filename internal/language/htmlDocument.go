@@ -1,11 +1,33 @@
 package language
 
 import (
-	"fmt"
+	"bufio"
+	"bytes"
+	"io"
+	"regexp"
 
-	"github.com/eevan78/translit/internal/exit"
+	"github.com/eevan78/translit/internal/dictionary"
+	"github.com/eevan78/translit/internal/messages"
 	"github.com/eevan78/translit/internal/terminal"
 	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	// A whole document has at least one of these, while a fragment has none
+	htmlDocumentMarkup = regexp.MustCompile(`(?i)<(!doctype|html|head|body)[\s>/]`)
+	// Elements that can be parsed only inside a specific parent element
+	htmlFragmentContexts = map[atom.Atom]atom.Atom{
+		atom.Tr:       atom.Tbody,
+		atom.Td:       atom.Tr,
+		atom.Th:       atom.Tr,
+		atom.Thead:    atom.Table,
+		atom.Tbody:    atom.Table,
+		atom.Tfoot:    atom.Table,
+		atom.Caption:  atom.Table,
+		atom.Colgroup: atom.Table,
+		atom.Col:      atom.Colgroup,
+	}
 )
 
 type HtmlDocument struct {
@@ -14,22 +36,13 @@ type HtmlDocument struct {
 	fop            *terminal.FileOperator
 }
 
-func (document *HtmlDocument) open() {
-	document.fop = &terminal.FileOperator{}
-	document.fop.Open(document.inputFilePath)
-	document.fop.Create(document.outputFilePath)
+func (document *HtmlDocument) open() error {
+	document.fop = &terminal.FileOperator{Markup: true}
+	return document.fop.OpenAndCreate(document.inputFilePath, document.outputFilePath)
 }
 
-func (document *HtmlDocument) transliterate() {
-	node, err := html.Parse(document.fop.Reader)
-	if err != nil {
-		exit.ExitWithError(err, document.getInputFilePath())
-	}
-	traverseHtmlNode(node)
-	if err := html.Render(document.fop.Writer, node); err != nil {
-		exit.ExitWithError(err, document.getInputFilePath())
-	}
-	_ = document.fop.Writer.Flush()
+func (document *HtmlDocument) transliterate() error {
+	return transliterateHtml(document.fop.Reader, document.fop.Writer)
 }
 
 func (document *HtmlDocument) getInputFilePath() string {
@@ -40,7 +53,88 @@ func (document *HtmlDocument) getOuputFilePath() string {
 	return document.outputFilePath
 }
 
-func (document *HtmlDocument) finalize() {
-	fmt.Printf("Успешно: %s \nу %s\n", document.inputFilePath, document.outputFilePath)
-	document.fop.Close()
+func (document *HtmlDocument) finalize() error {
+	if err := document.fop.Close(); err != nil {
+		return err
+	}
+	messages.Printf(messages.Success, document.inputFilePath, document.outputFilePath)
+	return nil
+}
+
+func (document *HtmlDocument) abort() {
+	document.fop.Abort()
+}
+
+// Transliterates HTML read from the reader and writes it to the writer, token by token with -stream, or else
+// as a whole document or a fragment.
+func transliterateHtml(reader io.Reader, writer *bufio.Writer) error {
+	if *dictionary.StreamPtr {
+		return transliterateHtmlStream(reader, writer)
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	if *dictionary.FragmentPtr || isHtmlFragment(data) {
+		if err := transliterateHtmlFragment(data, writer); err != nil {
+			return err
+		}
+		return writer.Flush()
+	}
+
+	node, err := html.Parse(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	traverseHtmlNode(node)
+	if err := html.Render(writer, node); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+func isHtmlFragment(data []byte) bool {
+	return !htmlDocumentMarkup.Match(data)
+}
+
+// Parses the fragment in the context of the element it can appear in, so that nothing is added to it,
+// transliterates it and renders only the nodes of the fragment.
+func transliterateHtmlFragment(data []byte, writer io.Writer) error {
+	contextAtom := atom.Body
+	if parent, ok := htmlFragmentContexts[firstHtmlStartTag(data)]; ok {
+		contextAtom = parent
+	}
+	context := &html.Node{Type: html.ElementNode, Data: contextAtom.String(), DataAtom: contextAtom}
+
+	nodes, err := html.ParseFragment(bytes.NewReader(data), context)
+	if err != nil {
+		return err
+	}
+	// attached to the context, the text nodes at the top of the fragment have a parent like any other
+	for _, node := range nodes {
+		context.AppendChild(node)
+	}
+	traverseHtmlNode(context)
+
+	for node := context.FirstChild; node != nil; node = node.NextSibling {
+		if err := html.Render(writer, node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func firstHtmlStartTag(data []byte) atom.Atom {
+	tokenizer := html.NewTokenizer(bytes.NewReader(data))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return 0
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			return atom.Lookup(name)
+		}
+	}
 }
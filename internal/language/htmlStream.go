@@ -0,0 +1,189 @@
+package language
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/eevan78/translit/internal/dictionary"
+	"golang.org/x/net/html"
+)
+
+var (
+	htmlEntity        = regexp.MustCompile(`&(#[0-9]+|#[xX][0-9a-fA-F]+|[a-zA-Z][a-zA-Z0-9]*);?`)
+	htmlLangAttribute = regexp.MustCompile(`(?i)(\s(?:xml:)?lang\s*=\s*)("[^"]*"|'[^']*'|[^\s"'>]+)`)
+	htmlRawAttribute  = regexp.MustCompile(`(\s)([^\s"'>/=]+)(\s*=\s*)("[^"]*"|'[^']*'|[^\s"'>]+)`)
+	htmlXmlNamespace  = regexp.MustCompile(`(?i)\s(xmlns|xml:lang)[\s=]`)
+	// Elements which have no end tag, so they are never put on the stack
+	htmlVoidElements = map[string]bool{
+		"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+		"input": true, "link": true, "meta": true, "param": true, "source": true, "track": true, "wbr": true,
+	}
+	// Elements which are implicitly closed by the start tag of the same element
+	htmlSelfClosingSiblings = map[string]bool{
+		"p": true, "li": true, "dt": true, "dd": true, "tr": true, "td": true, "th": true, "option": true,
+	}
+)
+
+// State of an open element, inherited by the elements inside it
+type htmlStreamFrame struct {
+	name             string
+	skip             bool // inside an element whose text is never transliterated
+	langAllowed      bool // decided by the closest lang attribute
+	translateAllowed bool // decided by the closest translate attribute
+}
+
+// Transliterates HTML read from the reader token by token, and writes it to the writer. Only the text, the attributes
+// selected with -attrs and -meta, the lang attribute of the html element and the charset declared in meta elements
+// are changed, while every other byte of the markup is copied as it is, so the output differs from the input only
+// where it has to. The same rules about the language and the elements which are not transliterated apply as for the
+// whole parsed document, but the open elements are tracked only from the tags, so the elements closed implicitly by
+// another element are recognized only for the same element, like p or li.
+func transliterateHtmlStream(reader io.Reader, writer *bufio.Writer) error {
+	tokenizer := html.NewTokenizer(reader)
+	stack := []htmlStreamFrame{{langAllowed: true, translateAllowed: true}}
+
+	for {
+		tokenType := tokenizer.Next()
+		// copied, because the tokenizer lowercases tag names and attribute keys in its buffer
+		raw := append([]byte(nil), tokenizer.Raw()...)
+		top := stack[len(stack)-1]
+
+		switch tokenType {
+		case html.ErrorToken:
+			if tokenizer.Err() == io.EOF {
+				return writer.Flush()
+			}
+			return tokenizer.Err()
+
+		case html.TextToken:
+			if !top.skip && top.langAllowed && top.translateAllowed && !allWhite(string(raw)) {
+				if _, err := writer.WriteString(transliterateHtmlRawText(string(raw))); err != nil {
+					return err
+				}
+				continue
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttributes := tokenizer.TagName()
+			if tokenType == html.StartTagToken && htmlSelfClosingSiblings[string(name)] && top.name == string(name) {
+				stack = stack[:len(stack)-1]
+				top = stack[len(stack)-1]
+			}
+			frame := htmlStreamFrame{
+				name:             string(name),
+				skip:             top.skip || htmlSkippedElements[string(name)],
+				langAllowed:      top.langAllowed,
+				translateAllowed: top.translateAllowed,
+			}
+			values := map[string]string{}
+			for hasAttributes {
+				var key, value []byte
+				key, value, hasAttributes = tokenizer.TagAttr()
+				values[string(key)] = string(value)
+				switch string(key) {
+				case "lang", "xml:lang":
+					if allowed, decided := languageAllowsTransliteration(string(value)); decided {
+						frame.langAllowed = allowed
+					}
+				case "translate":
+					frame.translateAllowed = !strings.EqualFold(strings.TrimSpace(string(value)), "no")
+				}
+			}
+
+			if frame.langAllowed && frame.translateAllowed {
+				raw = []byte(transliterateHtmlRawAttributes(string(raw), frame.name, values))
+			}
+			if frame.name == "meta" {
+				raw = []byte(replaceDeclaredCharset(string(raw)))
+			}
+			// the whole document is transliterated, whether its lang attribute is replaced or kept with -nolang
+			if frame.name == "html" && selectedDirection() != NoDirection {
+				if !*dictionary.NoLangPtr {
+					raw = []byte(setHtmlRawLanguage(string(raw)))
+				}
+				frame.langAllowed = true
+			}
+
+			if top.langAllowed && !frame.langAllowed && !frame.skip && frame.translateAllowed {
+				currentStats.countLangProtected()
+			}
+			if tokenType == html.StartTagToken && !htmlVoidElements[frame.name] {
+				stack = append(stack, frame)
+			}
+
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].name == string(name) {
+					stack = stack[:i]
+					break
+				}
+			}
+		}
+
+		if _, err := writer.Write(raw); err != nil {
+			return err
+		}
+	}
+}
+
+// Transliterates the text as found in the markup, leaving the character references as they are.
+func transliterateHtmlRawText(raw string) string {
+	var sb strings.Builder
+	for {
+		loc := htmlEntity.FindStringIndex(raw)
+		if loc == nil {
+			sb.WriteString(transliterateText(raw))
+			break
+		}
+		sb.WriteString(transliterateText(raw[:loc[0]]))
+		sb.WriteString(raw[loc[0]:loc[1]])
+		raw = raw[loc[1]:]
+	}
+	return sb.String()
+}
+
+// Transliterates the values of the attributes selected with -attrs and -meta in the start tag, keeping their quotes
+// and character references.
+func transliterateHtmlRawAttributes(tag string, element string, values map[string]string) string {
+	if *dictionary.HtmlAttributesPtr == "" && *dictionary.HtmlMetaPtr == "" {
+		return tag
+	}
+	return htmlRawAttribute.ReplaceAllStringFunc(tag, func(attribute string) string {
+		match := htmlRawAttribute.FindStringSubmatch(attribute)
+		value := match[4]
+		quote := ""
+		if strings.HasPrefix(value, "\"") || strings.HasPrefix(value, "'") {
+			quote, value = value[:1], value[1:len(value)-1]
+		}
+		if allWhite(value) || !htmlAttributeTransliterated(element, strings.ToLower(match[2]), values) {
+			return attribute
+		}
+		return match[1] + match[2] + match[3] + quote + transliterateHtmlRawText(value) + quote
+	})
+}
+
+// Sets the value of the lang attribute in the html start tag, keeping its quotes, or adds the attribute
+// if it is missing.
+func setHtmlRawLanguage(tag string) string {
+	value := transliteratedLanguageTag()
+	if htmlLangAttribute.MatchString(tag) {
+		return htmlLangAttribute.ReplaceAllStringFunc(tag, func(attribute string) string {
+			match := htmlLangAttribute.FindStringSubmatch(attribute)
+			quote := ""
+			if strings.HasPrefix(match[2], "\"") || strings.HasPrefix(match[2], "'") {
+				quote = match[2][:1]
+			}
+			return match[1] + quote + value + quote
+		})
+	}
+
+	key := "lang"
+	if htmlXmlNamespace.MatchString(tag) {
+		key = "xml:lang"
+	}
+	end := strings.TrimSuffix(strings.TrimSuffix(tag, ">"), "/")
+	return end + " " + key + "=\"" + value + "\"" + tag[len(end):]
+}
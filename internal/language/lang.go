@@ -0,0 +1,77 @@
+package language
+
+import (
+	"strings"
+
+	"github.com/eevan78/translit/internal/dictionary"
+)
+
+// Returns the primary language subtag of a BCP 47 language tag in lower case.
+func primaryLanguage(tag string) string {
+	primary, _, _ := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-")
+	return strings.ToLower(strings.TrimSpace(primary))
+}
+
+// Returns the script subtag of a BCP 47 language tag in lower case, or an empty string if there is none.
+// Subtags of the extensions, like the source script in sr-Cyrl-t-sr-Latn, are not considered.
+func languageScript(tag string) string {
+	subtags := strings.Split(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"), "-")
+	for _, subtag := range subtags[1:] {
+		if len(subtag) == 1 {
+			break
+		}
+		if len(subtag) == 4 {
+			return strings.ToLower(subtag)
+		}
+	}
+	return ""
+}
+
+// Decides from the value of a lang or xml:lang attribute whether the marked text should be transliterated.
+// Text in a language other than the language of the scheme, like Serbian, and the text marked with the script
+// it is transliterated from, should not be. The decided result is false when the attribute value is empty, which
+// means unknown language, so the decision is inherited from the enclosing element.
+func languageAllowsTransliteration(tag string) (allowed bool, decided bool) {
+	if strings.TrimSpace(tag) == "" {
+		return false, false
+	}
+	if !isSchemeLanguage(tag) {
+		return false, true
+	}
+
+	script := languageScript(tag)
+	if (*dictionary.L2cPtr && script == "latn") || (*dictionary.C2lPtr && script == "cyrl") {
+		return false, true
+	}
+	return true, true
+}
+
+// Reports whether the language of the tag is one of the languages the selected scheme applies to.
+func isSchemeLanguage(tag string) bool {
+	primary := primaryLanguage(tag)
+	for _, language := range dictionary.CurrentScheme().Languages {
+		if primary == language {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the language tag of a document transliterated in the direction selected by the flags, which
+// records both the target and the source script, and the standard romanization, if used. Text converted to ASCII
+// can come from either script, so only the target script is recorded.
+func transliteratedLanguageTag() string {
+	scheme := dictionary.CurrentScheme()
+	language := scheme.Languages[0]
+	switch selectedDirection() {
+	case LatinToCyrillic:
+		return language + "-Cyrl-t-" + language + "-Latn"
+	case ToASCII:
+		return language + "-Latn"
+	}
+	if scheme.Mechanism != "" {
+		// the transformation mechanism of the romanization, like sr-Latn-t-sr-Cyrl-m0-iso
+		return language + "-Latn-t-" + language + "-Cyrl-m0-" + scheme.Mechanism
+	}
+	return language + "-Latn-t-" + language + "-Cyrl"
+}
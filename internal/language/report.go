@@ -0,0 +1,156 @@
+package language
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/eevan78/translit/internal/dictionary"
+	"github.com/eevan78/translit/internal/messages"
+)
+
+var (
+	report       *Report
+	currentStats *Stats // statistics of the document being transliterated, nil when there is no report
+)
+
+// Report is the machine-readable summary of a run, written as JSON with the -report flag.
+type Report struct {
+	Version   string            `json:"version"`
+	Direction string            `json:"direction"`
+	Scheme    string            `json:"scheme"`
+	Documents []*DocumentReport `json:"documents"`
+	Totals    Stats             `json:"totals"`
+}
+
+// DocumentReport holds the statistics of a single document. The documents of an archive are named by the path
+// of the archive followed by their path inside it.
+type DocumentReport struct {
+	Input  string `json:"input"`
+	Output string `json:"output"`
+	Stats
+}
+
+// Stats counts the words changed by the transliteration, and the words and the parts of the text left intact.
+type Stats struct {
+	Words         int            `json:"words"`                // words changed by the transliteration
+	Foreign       map[string]int `json:"foreign"`              // foreign words left intact, by the rule which recognized them
+	Units         int            `json:"units"`                // measurement units left intact
+	DigraphSplits int            `json:"digraph_splits"`       // words whose digraphs are split into two letters
+	Protected     int            `json:"protected_regions"`    // regions of plain text between <| and |>
+	LangProtected int            `json:"lang_protected_spans"` // elements whose lang attribute stops the transliteration
+}
+
+func newStats() Stats {
+	return Stats{Foreign: map[string]int{
+		foreignCharacterCombinations: 0,
+		commonForeignWords:           0,
+		wholeForeignWords:            0,
+	}}
+}
+
+// Starts the report if it is requested and not already started by the enclosing archive. Returns whether
+// it was started.
+func startReport() bool {
+	if *dictionary.ReportPtr == "" || report != nil {
+		return false
+	}
+	report = &Report{
+		Version:   dictionary.ProgramVersion,
+		Direction: selectedDirection().String(),
+		Scheme:    dictionary.CurrentScheme().Name,
+		Documents: []*DocumentReport{},
+		Totals:    newStats(),
+	}
+	return true
+}
+
+// Adds the document to the report and returns its statistics. An archive has no statistics of its own,
+// but the documents inside it have.
+func (report *Report) startDocument(document Document) *Stats {
+	if report == nil {
+		return nil
+	}
+	if _, ok := document.(*ZipArchive); ok {
+		return nil
+	}
+
+	input, output := displayPath(document.getInputFilePath()), displayPath(document.getOuputFilePath())
+	if isStdIn() {
+		input, output = "-", "-"
+	}
+
+	documentReport := &DocumentReport{Input: input, Output: output, Stats: newStats()}
+	report.Documents = append(report.Documents, documentReport)
+	return &documentReport.Stats
+}
+
+// Sums up the statistics of the documents and writes the report to the file given with the flag.
+func finishReport() error {
+	defer func() {
+		report = nil
+		currentStats = nil
+	}()
+	for _, document := range report.Documents {
+		report.Totals.add(&document.Stats)
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(*dictionary.ReportPtr, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	if !isStdIn() {
+		messages.Printf(messages.ReportWritten, *dictionary.ReportPtr)
+	}
+	return nil
+}
+
+func (stats *Stats) add(other *Stats) {
+	stats.Words += other.Words
+	for rule, count := range other.Foreign {
+		stats.Foreign[rule] += count
+	}
+	stats.Units += other.Units
+	stats.DigraphSplits += other.DigraphSplits
+	stats.Protected += other.Protected
+	stats.LangProtected += other.LangProtected
+}
+
+// Counts the word by what the transliteration did with it. Words which are not changed, like numbers, are counted
+// only when they are recognized as foreign words or units.
+func (stats *Stats) countWord(word string, result string) {
+	if stats == nil {
+		return
+	}
+	latinToCyrillic := selectedDirection() == LatinToCyrillic
+	if result != word {
+		stats.Words++
+		if latinToCyrillic && splitDigraphs(word, dictionary.CurrentScheme()) != word {
+			stats.DigraphSplits++
+		}
+		return
+	}
+	if !latinToCyrillic {
+		return
+	}
+	switch rule, _ := foreignWordRule(word); rule {
+	case "", serbianWordWithForeignCombinations:
+	case measurementUnit:
+		stats.Units++
+	default:
+		stats.Foreign[rule]++
+	}
+}
+
+func (stats *Stats) countProtected() {
+	if stats != nil {
+		stats.Protected++
+	}
+}
+
+func (stats *Stats) countLangProtected() {
+	if stats != nil {
+		stats.LangProtected++
+	}
+}
@@ -0,0 +1,129 @@
+package language
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/eevan78/translit/internal/dictionary"
+	"github.com/eevan78/translit/internal/messages"
+	"github.com/eevan78/translit/internal/userdict"
+)
+
+// The terminal is used for the questions, because the standard input can be the text which is transliterated
+const terminalPath = "/dev/tty"
+
+var (
+	userDictionary *userdict.Dictionary // nil when there is neither the interactive mode nor the user dictionary
+	reviewTerminal io.ReadWriteCloser
+	reviewReader   *bufio.Reader
+	// Opens the terminal the questions are asked on
+	openReviewTerminal = func() (io.ReadWriteCloser, error) {
+		return os.OpenFile(terminalPath, os.O_RDWR, 0)
+	}
+)
+
+// Starts the review of the uncertain words with the decisions from the user dictionary, unless it is already started
+// by the enclosing archive. Returns whether it was started.
+func startReview() (bool, error) {
+	if userDictionary != nil || (!*dictionary.InteractivePtr && *dictionary.UserDictPtr == "") {
+		return false, nil
+	}
+	loaded := userdict.New()
+	if *dictionary.UserDictPtr != "" {
+		var err error
+		if loaded, err = userdict.Load(*dictionary.UserDictPtr); err != nil {
+			return false, fmt.Errorf("%s: %w", *dictionary.UserDictPtr, err)
+		}
+	}
+	if *dictionary.InteractivePtr {
+		terminal, err := openReviewTerminal()
+		if err != nil {
+			return false, err
+		}
+		reviewTerminal, reviewReader = terminal, bufio.NewReader(terminal)
+	}
+	userDictionary = loaded
+	return true, nil
+}
+
+func finishReview() {
+	userDictionary = nil
+	if reviewTerminal != nil {
+		reviewTerminal.Close()
+		reviewTerminal, reviewReader = nil, nil
+	}
+}
+
+// Transliterates the word to the Cyrillic script the way the user decided, and in the interactive mode asks the user
+// about the foreign words and the words with split digraphs the first time they are found. The decision applies to
+// the rest of the run, and it is saved to the user dictionary. Returns false when the rules decide about the word.
+func reviewWord(word string, capitals bool, context string, scheme *dictionary.Scheme) (string, bool) {
+	key := strings.ToLower(trimExcessiveCharacters(word))
+	if key == "" {
+		return "", false
+	}
+
+	decision, ok := userDictionary.Lookup(key)
+	if !ok {
+		if !*dictionary.InteractivePtr {
+			return "", false
+		}
+		explanation := explainWord(word, LatinToCyrillic, scheme, capitals)
+		if !isUncertain(explanation) {
+			return "", false
+		}
+		decision = askDecision(explanation, context, reviewReader, reviewTerminal)
+		userDictionary.Set(key, decision)
+		if *dictionary.UserDictPtr != "" {
+			// the decision still applies to the rest of the run, so the run goes on
+			if err := userDictionary.Save(*dictionary.UserDictPtr); err != nil {
+				messages.Fprintf(os.Stderr, messages.DecisionNotSaved, *dictionary.UserDictPtr, err)
+			}
+		}
+	}
+
+	switch decision {
+	case userdict.Convert:
+		return l2cWord(word, scheme, true), true
+	case userdict.Protect:
+		return fixPunctuation(word), true
+	}
+	return "", false
+}
+
+// Reports whether the word is on the edge of the rules: it is left intact as a foreign word, or its digraph is split.
+func isUncertain(explanation Explanation) bool {
+	switch explanation.Rule {
+	case foreignCharacterCombinations, commonForeignWords, wholeForeignWords, foreignPrefix:
+		return true
+	}
+	return explanation.Split != ""
+}
+
+// Shows the word in its context with the decision of the rules, and asks the user whether to accept it,
+// to transliterate the word, or to leave it intact. When the answer cannot be read, the decision of the rules
+// is accepted.
+func askDecision(explanation Explanation, context string, reader *bufio.Reader, writer io.Writer) userdict.Decision {
+	context = strings.Replace(strings.TrimSpace(context), explanation.Word, "»"+explanation.Word+"«", 1)
+	fmt.Fprintf(writer, "\n%s\n%s\n", context, explanation)
+	for {
+		messages.Fprintf(writer, messages.ReviewPrompt)
+		answer, err := reader.ReadString('\n')
+		if err != nil {
+			messages.Fprintf(writer, messages.AnswerNotRead, err)
+			return userdict.Accept
+		}
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		// the letters of the answers are the same whatever the language of the messages
+		case "", "п", "p", "a":
+			return userdict.Accept
+		case "с", "s", "c":
+			return userdict.Convert
+		case "з", "z":
+			return userdict.Protect
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package language
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eevan78/translit/internal/dictionary"
+	"github.com/eevan78/translit/internal/userdict"
+)
+
+// Terminal whose answers are read from the string, and whose questions are kept in the buffer
+type fakeTerminal struct {
+	io.Reader
+	bytes.Buffer
+}
+
+func (terminal *fakeTerminal) Read(p []byte) (int, error) {
+	return terminal.Reader.Read(p)
+}
+
+func (terminal *fakeTerminal) Close() error {
+	return nil
+}
+
+func TestAskDecision(t *testing.T) {
+	explanation := explainWord("Adobe", LatinToCyrillic, dictionary.Schemes["sr"], false)
+	for answers, expected := range map[string]userdict.Decision{
+		"\n":       userdict.Accept,
+		"п\n":      userdict.Accept,
+		"s\n":      userdict.Convert,
+		"С\n":      userdict.Convert,
+		"z\n":      userdict.Protect,
+		"x\nз\n":   userdict.Protect,
+		"":         userdict.Accept,
+		"nešto\n":  userdict.Accept,
+		"  c  \n":  userdict.Convert,
+		"z\ns\n\n": userdict.Protect,
+	} {
+		var questions bytes.Buffer
+		decision := askDecision(explanation, "Adobe je napravio program.", bufio.NewReader(strings.NewReader(answers)), &questions)
+		if decision != expected {
+			t.Errorf("Одлука за одговор %q је %v, а требало је да буде %v", answers, decision, expected)
+		}
+		if !strings.Contains(questions.String(), "»Adobe« je napravio program.") {
+			t.Errorf("Реч није означена у питању:\n%s", questions.String())
+		}
+	}
+}
+
+func TestReviewWord(t *testing.T) {
+	userDictPath := filepath.Join(t.TempDir(), "recnik.txt")
+	*dictionary.L2cPtr = true
+	*dictionary.InteractivePtr = true
+	*dictionary.UserDictPtr = userDictPath
+	defer func() {
+		*dictionary.L2cPtr, *dictionary.InteractivePtr, *dictionary.UserDictPtr = false, false, ""
+	}()
+
+	// the second Adobe is not asked about again, and kuća is not uncertain
+	terminal := &fakeTerminal{Reader: strings.NewReader("с\ns\nз\n\n")}
+	defer func(open func() (io.ReadWriteCloser, error)) { openReviewTerminal = open }(openReviewTerminal)
+	openReviewTerminal = func() (io.ReadWriteCloser, error) { return terminal, nil }
+
+	if started, err := startReview(); !started || err != nil {
+		t.Fatalf("Преглед није почео: %v", err)
+	}
+	result := transliterateText("Adobe i bitcoin-nadživeo, kuća nadživeo injekcija ADOBE")
+	finishReview()
+
+	expected := "Адобе и bitcoin-надживео, кућа nadživeo инјекција АДОБЕ"
+	if result != expected {
+		t.Fatalf("Резултат је %q, а требало је да буде %q", result, expected)
+	}
+
+	saved, err := os.ReadFile(userDictPath)
+	if err != nil {
+		t.Fatalf("Кориснички речник није сачуван: %v", err)
+	}
+	for _, line := range []string{"adobe convert", "bitcoin-nadživeo convert", "nadživeo protect", "injekcija accept"} {
+		if !strings.Contains(string(saved), line+"\n") {
+			t.Errorf("У корисничком речнику нема %q:\n%s", line, saved)
+		}
+	}
+}
@@ -7,32 +7,48 @@ import (
 	"strings"
 
 	"github.com/eevan78/translit/internal/dictionary"
-	"github.com/eevan78/translit/internal/exit"
+	"github.com/eevan78/translit/internal/terminal"
 )
 
 type StdIn struct {
-	reader *bufio.Reader
-	writer *bufio.Writer
+	reader  *bufio.Reader
+	writer  *bufio.Writer
+	encoder io.Closer
 }
 
-func (document *StdIn) open() {
-	document.reader = bufio.NewReader(os.Stdin)
-	document.writer = bufio.NewWriter(os.Stdout)
+func (document *StdIn) open() (err error) {
+	if document.reader, err = terminal.DecodeReader(os.Stdin); err != nil {
+		return err
+	}
+	document.writer, document.encoder, err = terminal.EncodeWriter(os.Stdout, *dictionary.HtmlPtr)
+	return err
 }
 
-func (document *StdIn) transliterate() {
+func (document *StdIn) transliterate() error {
+	if *dictionary.HtmlPtr {
+		return transliterateHtml(document.reader, document.writer)
+	}
 
 loop:
 	for {
 		switch line, err := document.reader.ReadString('\n'); err {
 		case nil:
+			if *dictionary.SlugPtr {
+				if _, err = document.writer.WriteString(Slug(line, dictionary.CurrentScheme()) + "\n"); err != nil {
+					return err
+				}
+				_ = document.writer.Flush()
+				continue
+			}
 			lineprefix := dictionary.Whitepref.FindString(line)
 			words := strings.Fields(line)
+			capitals := sentenceCapitals(words)
 			doit := true
 			for n := range words {
 				if strings.HasPrefix(words[n], "<|") {
 					doit = false                                  // Do not transliterate
 					words[n] = strings.TrimPrefix(words[n], "<|") // Remove marker of the beginning
+					currentStats.countProtected()
 					words[n] = fixPunctuation(words[n])
 				}
 				if strings.HasSuffix(words[n], "|>") {
@@ -45,16 +61,7 @@ loop:
 					words[n] = fixPunctuation(words[n])
 					continue
 				}
-				if *dictionary.L2cPtr {
-					index := transliterationIndexOfWordStartsWith(strings.ToLower(words[n]), dictionary.WholeForeignWords, "-")
-					if index >= 0 {
-						words[n] = string(words[n][:index]) + l2c(string(words[n][index:]))
-					} else if !looksLikeForeignWord(words[n]) {
-						words[n] = l2c(words[n])
-					}
-				} else if *dictionary.C2lPtr {
-					words[n] = c2l(words[n])
-				}
+				words[n] = transliterateWord(words[n], capitals[n], line)
 			}
 
 			if lineprefix != "" && lineprefix != "\n" && len(words) != 0 {
@@ -63,7 +70,7 @@ loop:
 			outl := strings.Join(words, " ")
 			outl += "\n"
 			if _, err = document.writer.WriteString(outl); err != nil {
-				exit.ExitWithError(err, "стандардним улазом")
+				return err
 			}
 			_ = document.writer.Flush()
 
@@ -71,9 +78,10 @@ loop:
 			break loop
 
 		default:
-			exit.ExitWithError(err, "стандардним улазом")
+			return err
 		}
 	}
+	return document.writer.Flush()
 }
 
 func (document *StdIn) getInputFilePath() string {
@@ -84,5 +92,9 @@ func (document *StdIn) getOuputFilePath() string {
 	return ""
 }
 
-func (document *StdIn) finalize() {
+func (document *StdIn) finalize() error {
+	return document.encoder.Close()
+}
+
+func (document *StdIn) abort() {
 }
@@ -1,12 +1,11 @@
 package language
 
 import (
-	"fmt"
 	"io"
 	"strings"
 
 	"github.com/eevan78/translit/internal/dictionary"
-	"github.com/eevan78/translit/internal/exit"
+	"github.com/eevan78/translit/internal/messages"
 	"github.com/eevan78/translit/internal/terminal"
 )
 
@@ -16,25 +15,33 @@ type TextDocument struct {
 	fop            *terminal.FileOperator
 }
 
-func (document *TextDocument) open() {
+func (document *TextDocument) open() error {
 	document.fop = &terminal.FileOperator{}
-	document.fop.Open(document.inputFilePath)
-	document.fop.Create(document.outputFilePath)
+	return document.fop.OpenAndCreate(document.inputFilePath, document.outputFilePath)
 }
 
-func (document *TextDocument) transliterate() {
+func (document *TextDocument) transliterate() error {
 
 loop:
 	for {
 		switch line, err := document.fop.Reader.ReadString('\n'); err {
 		case nil:
+			if *dictionary.SlugPtr {
+				if _, err = document.fop.Writer.WriteString(Slug(line, dictionary.CurrentScheme()) + "\n"); err != nil {
+					return err
+				}
+				_ = document.fop.Writer.Flush()
+				continue
+			}
 			lineprefix := dictionary.Whitepref.FindString(line)
 			words := strings.Fields(line)
+			capitals := sentenceCapitals(words)
 			doit := true
 			for n := range words {
 				if strings.HasPrefix(words[n], "<|") {
 					doit = false                                  // Do not transliterate
 					words[n] = strings.TrimPrefix(words[n], "<|") // Remove marker of the beginning
+					currentStats.countProtected()
 					words[n] = fixPunctuation(words[n])
 				}
 				if strings.HasSuffix(words[n], "|>") {
@@ -47,16 +54,7 @@ loop:
 					words[n] = fixPunctuation(words[n])
 					continue
 				}
-				if *dictionary.L2cPtr {
-					index := transliterationIndexOfWordStartsWith(strings.ToLower(words[n]), dictionary.WholeForeignWords, "-")
-					if index >= 0 {
-						words[n] = string(words[n][:index]) + l2c(string(words[n][index:]))
-					} else if !looksLikeForeignWord(words[n]) {
-						words[n] = l2c(words[n])
-					}
-				} else if *dictionary.C2lPtr {
-					words[n] = c2l(words[n])
-				}
+				words[n] = transliterateWord(words[n], capitals[n], line)
 			}
 
 			if lineprefix != "" && lineprefix != "\n" && len(words) != 0 {
@@ -65,7 +63,7 @@ loop:
 			outl := strings.Join(words, " ")
 			outl += "\n"
 			if _, err = document.fop.Writer.WriteString(outl); err != nil {
-				exit.ExitWithError(err, document.getInputFilePath())
+				return err
 			}
 			_ = document.fop.Writer.Flush()
 
@@ -73,9 +71,10 @@ loop:
 			break loop
 
 		default:
-			exit.ExitWithError(err, document.getInputFilePath())
+			return err
 		}
 	}
+	return document.fop.Writer.Flush()
 }
 
 func (document *TextDocument) getInputFilePath() string {
@@ -86,7 +85,14 @@ func (document *TextDocument) getOuputFilePath() string {
 	return document.outputFilePath
 }
 
-func (document *TextDocument) finalize() {
-	fmt.Printf("Успешно: %s \nу %s\n", document.inputFilePath, document.outputFilePath)
-	document.fop.Close()
+func (document *TextDocument) finalize() error {
+	if err := document.fop.Close(); err != nil {
+		return err
+	}
+	messages.Printf(messages.Success, document.inputFilePath, document.outputFilePath)
+	return nil
+}
+
+func (document *TextDocument) abort() {
+	document.fop.Abort()
 }
@@ -2,14 +2,20 @@ package language
 
 import (
 	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"unicode"
 	"unicode/utf8"
 
 	"github.com/beevik/etree"
+	"github.com/eevan78/translit/internal/charset"
 	"github.com/eevan78/translit/internal/dictionary"
-	"github.com/eevan78/translit/internal/exit"
+	"github.com/eevan78/translit/internal/messages"
 	"github.com/eevan78/translit/internal/terminal"
 	"github.com/gabriel-vasile/mimetype"
 	"golang.org/x/net/html"
@@ -17,60 +23,84 @@ import (
 
 var (
 	acceptedMime = map[string]string{
-		"text":  "text/plain; charset=utf-8",
-		"html":  "text/html; charset=utf-8",
+		"text":  "text/plain",
+		"html":  "text/html",
 		"xhtml": "application/xhtml+xml",
-		"xml":   "text/xml; charset=utf-8",
+		"xml":   "text/xml",
 		"zip":   "application/zip",
+		"csv":   "text/csv",
+		"tsv":   "text/tab-separated-values",
+		"xliff": "application/x-xliff+xml",
+	}
+	// Elements whose text content is never transliterated
+	htmlSkippedElements = map[string]bool{
+		"script":   true,
+		"style":    true,
+		"template": true,
+		"code":     true,
+		"pre":      true,
+		"kbd":      true,
+		"samp":     true,
+		"var":      true,
+		"textarea": true,
 	}
 )
 
 func looksLikeForeignWord(word string) bool {
+	rule, _ := foreignWordRule(word)
+	return rule != "" && rule != serbianWordWithForeignCombinations
+}
+
+// Returns the rule by which the word is recognized as foreign, or a measurement unit, and left intact when
+// transliterating to the Cyrillic script, together with the entry of the list which matched. Serbian words
+// with foreign character combinations are recognized first, so that they are transliterated. Returns an empty
+// rule for the other words which are transliterated.
+func foreignWordRule(word string) (rule string, match string) {
 	trimmedWord := trimExcessiveCharacters(word)
 	processed := strings.ToLower(trimmedWord)
 	if processed == "" {
-		return false
+		return "", ""
 	}
 
-	if wordStartsWith(processed, dictionary.SerbianWordsWithForeignCharacterCombinations) {
-		return false
+	if match, ok := wordStartsWith(processed, dictionary.SerbianWordsWithForeignCharacterCombinations); ok {
+		return serbianWordWithForeignCombinations, match
 	}
 
-	if wordContainsString(processed, dictionary.ForeignCharacterCombinations) {
-		return true
+	if match, ok := wordContainsString(processed, dictionary.ForeignCharacterCombinations); ok {
+		return foreignCharacterCombinations, match
 	}
 
-	if wordStartsWith(processed, dictionary.CommonForeignWords) {
-		return true
+	if match, ok := wordStartsWith(processed, dictionary.CommonForeignWords); ok {
+		return commonForeignWords, match
 	}
 
 	if wordIsEqualTo(processed, dictionary.WholeForeignWords) {
-		return true
+		return wholeForeignWords, processed
 	}
 
 	if wordContainsMeasurementUnit(trimmedWord) {
-		return true
+		return measurementUnit, trimmedWord
 	}
 
-	return false
+	return "", ""
 }
 
-func wordStartsWith(word string, array []string) bool {
+func wordStartsWith(word string, array []string) (string, bool) {
 	for _, arrayWord := range array {
 		if strings.HasPrefix(word, arrayWord) {
-			return true
+			return arrayWord, true
 		}
 	}
-	return false
+	return "", false
 }
 
-func wordContainsString(word string, array []string) bool {
+func wordContainsString(word string, array []string) (string, bool) {
 	for _, arrayWord := range array {
 		if strings.Contains(word, arrayWord) {
-			return true
+			return arrayWord, true
 		}
 	}
-	return false
+	return "", false
 }
 
 func wordIsEqualTo(word string, array []string) bool {
@@ -112,25 +142,34 @@ func wordContainsMeasurementUnit(word string) bool {
 	return regExp.MatchString(word)
 }
 
-func splitDigraphs(str string) string {
+func splitDigraphs(str string, scheme *dictionary.Scheme) string {
+	strout, _ := splitDigraphsByExceptions(str, scheme)
+	return strout
+}
+
+// Splits the digraphs of the word which begins like one of the digraph exceptions, and returns the split word
+// with the exceptions which matched.
+func splitDigraphsByExceptions(str string, scheme *dictionary.Scheme) (string, []string) {
 	lowercaseStr := strings.ToLower(trimExcessiveCharacters((str)))
 	strout := strings.Clone(str)
-	for digraph := range dictionary.DigraphExceptions {
+	var exceptions []string
+	for digraph := range scheme.DigraphExceptions {
 		if !strings.Contains(lowercaseStr, digraph) {
 			continue
 		}
-		for _, word := range dictionary.DigraphExceptions[digraph] {
+		for _, word := range scheme.DigraphExceptions[digraph] {
 			if !strings.HasPrefix(lowercaseStr, word) {
 				continue
 			}
+			exceptions = append(exceptions, word)
 			// Split all possible occurrences, regardless of case.
-			for key, word := range dictionary.DigraphReplacements[digraph] {
+			for key, word := range scheme.DigraphReplacements[digraph] {
 				strout = strings.Replace(strout, key, word, 1)
 			}
 			break
 		}
 	}
-	return strout
+	return strout, exceptions
 }
 
 func fixPunctuation(w string) string {
@@ -139,17 +178,25 @@ func fixPunctuation(w string) string {
 	return w
 }
 
-func l2c(s string) string {
+func l2c(s string, scheme *dictionary.Scheme) string {
+	if scheme.L2c == nil {
+		return s
+	}
+	s = fixPunctuation(s)
+	s = splitDigraphs(s, scheme)
+	return l2cLetters(s, scheme)
+}
+
+// Transliterates the word to the Cyrillic script letter by letter, without the digraph exceptions.
+func l2cLetters(s string, scheme *dictionary.Scheme) string {
 	result := ""
 	w := 0
-	s = fixPunctuation(s)
-	s = splitDigraphs(s)
 	for i, runeValue := range s {
 		if w > 1 {
 			w -= 1
 			continue
 		}
-		value, prefixLen, ok := dictionary.Tbl.SearchPrefixInString(s[i:])
+		value, prefixLen, ok := scheme.L2c.SearchPrefixInString(s[i:])
 		if ok {
 			result += value
 			w = utf8.RuneCountInString(s[i : i+prefixLen])
@@ -162,31 +209,181 @@ func l2c(s string) string {
 	return result
 }
 
-func Uppercase(s string) string {
-	up := ""
-	for _, runeValue := range s {
-		up += string(unicode.ToUpper(runeValue))
-	}
-	return up
-}
-
-func c2l(s string) string {
+// Transliterates the word to the Latin script. A Cyrillic letter written with a Latin digraph, like Љ, takes
+// the case of the letter next to it, so ЉУТ becomes LJUT and Љут becomes Ljut. A letter standing alone, like
+// an initial, is in capitals only when the sentence around it is in capitals.
+func c2l(s string, scheme *dictionary.Scheme, capitals bool) string {
 	result := ""
 	s = fixPunctuation(s)
 
-	for _, runeValue := range s {
-		value, ok := dictionary.Tbl1[string(runeValue)]
+	for i := 0; i < len(s); {
+		value, prefixLen, ok := scheme.C2l.SearchPrefixInString(s[i:])
 		if ok {
+			if slices.Contains(scheme.Digraphs, value) && digraphInCapitals(s[:i], s[i+prefixLen:], capitals) {
+				value = strings.ToUpper(value)
+			}
 			result += value
+			i += prefixLen
 		} else {
-			result += string(runeValue)
+			_, size := utf8.DecodeRuneInString(s[i:])
+			result += s[i : i+size]
+			i += size
+		}
+	}
+	return result
+}
+
+// Decides the case of a digraph from the next letter in the word, or from the previous one at the end of
+// the word. Letters of an acronym, like Џ.Б., are next to each other across the dot.
+func digraphInCapitals(before string, after string, capitals bool) bool {
+	if next, ok := adjacentLetter(after, false); ok {
+		return unicode.IsUpper(next)
+	}
+	if previous, ok := adjacentLetter(before, true); ok {
+		return unicode.IsUpper(previous)
+	}
+	return capitals
+}
+
+// Returns the first letter of the text, or the last one when looking backwards, skipping the combining marks
+// and a single dot.
+func adjacentLetter(s string, backwards bool) (rune, bool) {
+	dots := 0
+	for s != "" {
+		var runeValue rune
+		var size int
+		if backwards {
+			runeValue, size = utf8.DecodeLastRuneInString(s)
+			s = s[:len(s)-size]
+		} else {
+			runeValue, size = utf8.DecodeRuneInString(s)
+			s = s[size:]
+		}
+		switch {
+		case unicode.IsLetter(runeValue):
+			return runeValue, true
+		case runeValue == '.' && dots == 0:
+			dots++
+		case !unicode.Is(unicode.Mn, runeValue):
+			return 0, false
 		}
 	}
-	if dictionary.Fixdigraphs.MatchString(result) {
-		return Uppercase(result)
-	} else {
-		return result
+	return 0, false
+}
+
+// Reports for every word whether the sentence it belongs to is written in capitals, like a headline. A sentence
+// ends with a word ending with . ! ? or …, except for an initial, and it is in capitals when it has at least two
+// letters and none of them is lower case.
+func sentenceCapitals(words []string) []bool {
+	result := make([]bool, len(words))
+	start, upper, lower := 0, 0, 0
+	for n, word := range words {
+		letters := 0
+		for _, runeValue := range word {
+			if unicode.IsLetter(runeValue) {
+				letters++
+			}
+			if unicode.IsUpper(runeValue) {
+				upper++
+			} else if unicode.IsLower(runeValue) {
+				lower++
+			}
+		}
+		last, _ := utf8.DecodeLastRuneInString(strings.TrimRight(word, "\"”’)»"))
+		initial := letters == 1 && last == '.'
+		if n == len(words)-1 || (!initial && strings.ContainsRune(".!?…", last)) {
+			for i := start; i <= n; i++ {
+				result[i] = lower == 0 && upper >= 2
+			}
+			start, upper, lower = n+1, 0, 0
+		}
+	}
+	return result
+}
+
+// Transliterates a single word in the direction selected by the flags, after restoring its diacritics
+// if requested. Capitals tells whether the sentence of the word is written in capitals, and the context is the text
+// around the word, like its line, shown when the user reviews the word.
+func transliterateWord(word string, capitals bool, context string) string {
+	if *dictionary.DiacriticsPtr {
+		word = restoreDiacritics(word)
+	}
+	direction, scheme := selectedDirection(), dictionary.CurrentScheme()
+	result, reviewed := "", false
+	if direction == LatinToCyrillic && userDictionary != nil {
+		result, reviewed = reviewWord(word, capitals, context, scheme)
 	}
+	switch {
+	case reviewed:
+	case *dictionary.ExplainPtr:
+		explanation := explainWord(word, direction, scheme, capitals)
+		fmt.Fprintln(os.Stderr, explanation)
+		result = explanation.Result
+	default:
+		result = transliterateWordTo(word, direction, scheme, capitals)
+	}
+	currentStats.countWord(word, result)
+	return result
+}
+
+// Transliterates a single word in the given direction with the scheme. When transliterating to the Cyrillic script, foreign words
+// are left intact, and only the part after a foreign prefix joined with a hyphen is transliterated.
+func transliterateWordTo(word string, direction Direction, scheme *dictionary.Scheme, capitals bool) string {
+	switch direction {
+	case LatinToCyrillic:
+		return l2cWord(word, scheme, false)
+	case CyrillicToLatin:
+		return c2l(word, scheme, capitals)
+	case ToASCII:
+		return c2a(word, scheme, capitals)
+	}
+	return word
+}
+
+// Transliterates the word to the Cyrillic script, leaving intact the foreign words and the foreign prefix joined
+// with a hyphen. The word converted by the decision of the user is not taken as foreign, but its digraphs are
+// split by the same rules and its foreign prefix still stays intact.
+func l2cWord(word string, scheme *dictionary.Scheme, converted bool) string {
+	index := transliterationIndexOfWordStartsWith(strings.ToLower(word), dictionary.WholeForeignWords, "-")
+	switch {
+	case index >= 0:
+		return word[:index] + l2c(word[index:], scheme)
+	case converted || !looksLikeForeignWord(word):
+		return l2c(word, scheme)
+	}
+	return word
+}
+
+// Transliterates every word of the text, keeping all the whitespace between the words as it is.
+func transliterateText(text string) string {
+	return mapWords(text, func(word string, capitals bool) string {
+		return transliterateWord(word, capitals, text)
+	})
+}
+
+// Transliterates the text in the given direction with the scheme, keeping all the whitespace between the words
+// as it is. Unlike the transliteration of documents, it does not depend on the flags.
+func TransliterateText(text string, direction Direction, scheme *dictionary.Scheme) string {
+	return mapWords(text, func(word string, capitals bool) string {
+		return transliterateWordTo(word, direction, scheme, capitals)
+	})
+}
+
+// Maps every word of the text, telling the mapping whether the sentence of the word is written in capitals.
+func mapWords(text string, mapping func(string, bool) string) string {
+	words := dictionary.Whitespace.Split(text, -1)
+	spaces := dictionary.Whitespace.FindAllString(text, -1)
+	capitals := sentenceCapitals(words)
+	var sb strings.Builder
+	for n, word := range words {
+		if word != "" {
+			sb.WriteString(mapping(word, capitals[n]))
+		}
+		if n < len(spaces) {
+			sb.WriteString(spaces[n])
+		}
+	}
+	return sb.String()
 }
 
 func allWhite(s string) bool {
@@ -204,26 +401,13 @@ func traverseHtmlNode(n *html.Node) {
 	switch n.Type {
 	case html.ElementNode:
 		// Properly adjust the lang attribute, or add it if it's missing
-		if n.Data == "html" {
+		if n.Data == "html" && !*dictionary.NoLangPtr {
 			namespace := ""
 			notexist := true
-			if *dictionary.L2cPtr {
-				for i, attrib := range n.Attr {
-					if attrib.Key == "lang" || attrib.Key == "xml:lang" {
-						n.Attr[i].Val = "sr-Cyrl-t-sr-Latn"
-						notexist = false
-					}
-					if attrib.Key == "xml:lang" || attrib.Key == "xmlns" {
-						namespace = "xml"
-					}
-				}
-				if notexist {
-					n.Attr = append(n.Attr, html.Attribute{Namespace: namespace, Key: "lang", Val: "sr-Cyrl-t-sr-Latn"})
-				}
-			} else if *dictionary.C2lPtr {
+			if selectedDirection() != NoDirection {
 				for i, attrib := range n.Attr {
 					if attrib.Key == "lang" || attrib.Key == "xml:lang" {
-						n.Attr[i].Val = "sr-Latn-t-sr-Cyrl"
+						n.Attr[i].Val = transliteratedLanguageTag()
 						notexist = false
 					}
 					if attrib.Key == "xml:lang" || attrib.Key == "xmlns" {
@@ -231,28 +415,27 @@ func traverseHtmlNode(n *html.Node) {
 					}
 				}
 				if notexist {
-					n.Attr = append(n.Attr, html.Attribute{Namespace: namespace, Key: "lang", Val: "sr-Latn-t-sr-Cyrl"})
+					n.Attr = append(n.Attr, html.Attribute{Namespace: namespace, Key: "lang", Val: transliteratedLanguageTag()})
 				}
 			}
 		}
+		if htmlLanguageProtects(n) {
+			currentStats.countLangProtected()
+		}
+		if shouldTransliterateAttributes(n) {
+			transliterateHtmlAttributes(n)
+		}
+		adjustHtmlCharset(n)
 	case html.TextNode:
-		// Transliterate if text is not inside a script or a style element
+		// Transliterate if text is not inside an element which should not be transliterated
 		if !allWhite(n.Data) && n.Parent.Type == html.ElementNode && shouldTransliterate(n) {
 			nodeprefix := dictionary.Whitepref.FindString(n.Data)
 			nodesuffix := dictionary.Whitesuff.FindString(n.Data)
 			words := strings.Fields(n.Data)
+			capitals := sentenceCapitals(words)
 
 			for w := range words {
-				if *dictionary.L2cPtr {
-					index := transliterationIndexOfWordStartsWith(strings.ToLower(words[w]), dictionary.WholeForeignWords, "-")
-					if index >= 0 {
-						words[w] = string(words[w][:index]) + l2c(string(words[w][index:]))
-					} else if !looksLikeForeignWord(words[w]) {
-						words[w] = l2c(words[w])
-					}
-				} else { // *c2lPtr
-					words[w] = c2l(words[w])
-				}
+				words[w] = transliterateWord(words[w], capitals[w], n.Data)
 			}
 
 			// Preserve the whitespace at the beginning and at the end of the node data
@@ -268,45 +451,154 @@ func traverseHtmlNode(n *html.Node) {
 }
 
 // Checks whether a text node should be transliterated. Returns true if it should, and false otherwise.
-// A node should not be transliterated if its parent node is script or style.
-// Also, node should not be transliterated if it has a lang attribute with a value of Latin script
-// when transliteration is to the Cyrillic script, or if it has a lang attribute with a value of Cyrillic script
-// when transliteration is to the Latin script.
+// A node should not be transliterated if it is inside a script, style, template or an element for the computer code
+// and the user input, like code, pre or textarea. Also, node should not be transliterated if it is inside an element
+// with translate="no", or if the language inherited from the closest element with a lang attribute is not Serbian,
+// or it is Serbian in the script the text is transliterated from.
 func shouldTransliterate(n *html.Node) bool {
+	return htmlElementAllowsTransliteration(n.Parent, true)
+}
+
+// Checks whether attributes of an element should be transliterated. The same rules apply as for the text nodes,
+// but the attributes of the elements for the computer code and the user input are transliterated.
+func shouldTransliterateAttributes(n *html.Node) bool {
+	return htmlElementAllowsTransliteration(n, false)
+}
 
-	if n.Parent.Data == "script" || n.Parent.Data == "style" {
+// Checks whether the lang attribute of the element stops the transliteration of the text inside it, which would
+// be transliterated otherwise. The lang attribute of the html element never stops it.
+func htmlLanguageProtects(n *html.Node) bool {
+	if n.Data == "html" {
 		return false
 	}
+	for _, attrib := range n.Attr {
+		if attrib.Key == "lang" || attrib.Key == "xml:lang" {
+			allowed, decided := languageAllowsTransliteration(attrib.Val)
+			return decided && !allowed && !htmlSkippedElements[n.Data] && htmlElementAllowsTransliteration(n.Parent, true)
+		}
+	}
+	return false
+}
 
-	attr := ""
+// Checks whether the text and the attributes of the element are transliterated by the closest translate and lang
+// attributes. The lang attribute of the html element is not checked, because the whole document is transliterated
+// and the attribute is replaced by the tag of the output, unless it is kept with -nolang.
+func htmlElementAllowsTransliteration(n *html.Node, skipElements bool) bool {
+	langDecided := false
+	translateDecided := false
 
-	if *dictionary.L2cPtr {
-		attr = "sr-Latn"
-	} else { // *c2lPtr
-		attr = "sr-Cyrl"
+	for element := n; element != nil; element = element.Parent {
+		if element.Type != html.ElementNode {
+			continue
+		}
+		if skipElements && htmlSkippedElements[element.Data] {
+			return false
+		}
+		for _, attrib := range element.Attr {
+			switch {
+			case attrib.Key == "translate" && !translateDecided:
+				translateDecided = true
+				if strings.EqualFold(strings.TrimSpace(attrib.Val), "no") {
+					return false
+				}
+			case (attrib.Key == "lang" || attrib.Key == "xml:lang") && !langDecided && element.Data != "html":
+				if allowed, decided := languageAllowsTransliteration(attrib.Val); decided {
+					langDecided = true
+					if !allowed {
+						return false
+					}
+				}
+			}
+		}
 	}
 
-	shouldTranslit := true
-	if n.Parent.Data == "span" {
-		for _, k := range n.Parent.Attr {
-			if (k.Key == "lang" || k.Key == "xml:lang") && k.Val == attr {
-				shouldTranslit = false
-				break
-			}
+	return true
+}
+
+// Transliterates the user-visible attributes of an element listed in the flag, and the content of the meta elements
+// whose name or property is listed in the flag.
+func transliterateHtmlAttributes(n *html.Node) {
+	values := map[string]string{}
+	for _, attrib := range n.Attr {
+		values[attrib.Key] = attrib.Val
+	}
+	for i, attrib := range n.Attr {
+		if !allWhite(attrib.Val) && htmlAttributeTransliterated(n.Data, attrib.Key, values) {
+			n.Attr[i].Val = transliterateText(attrib.Val)
+		}
+	}
+}
+
+// Reports whether the attribute of the element is transliterated by -attrs and -meta, given the values of all
+// attributes of the element. The value attribute is visible only on buttons.
+func htmlAttributeTransliterated(element string, key string, values map[string]string) bool {
+	attributes := listFlag(*dictionary.HtmlAttributesPtr)
+	switch {
+	case key == "content" && element == "meta":
+		metaNames := listFlag(*dictionary.HtmlMetaPtr)
+		if wordIsEqualTo(strings.ToLower(strings.TrimSpace(values["name"])), metaNames) ||
+			wordIsEqualTo(strings.ToLower(strings.TrimSpace(values["property"])), metaNames) {
+			return true
 		}
+	case key == "value":
+		return element == "input" && wordIsEqualTo(strings.ToLower(values["type"]), []string{"button", "submit", "reset"}) &&
+			wordIsEqualTo("value", attributes)
+	}
+	return wordIsEqualTo(key, attributes)
+}
 
+func htmlAttributeValue(n *html.Node, key string) string {
+	for _, attrib := range n.Attr {
+		if attrib.Key == key {
+			return attrib.Val
+		}
 	}
+	return ""
+}
 
-	return shouldTranslit
+// Reports whether the file is left out by a pattern from -skip, which is matched against its name regardless
+// of the case.
+func isSkipped(filePath string) bool {
+	name := strings.ToLower(filepath.Base(filePath))
+	for _, pattern := range listFlag(*dictionary.SkipPtr) {
+		if matched, _ := filepath.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Splits a comma separated flag value into a list of trimmed, lower case items.
+func listFlag(value string) []string {
+	list := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
 }
 
 // Traverses through the XML starting from the given xml element (node). Firstly, it transliterates text which can be mixed
 // with other inner xml elements within this node. Then, it goes through the node and recursively do the traversal.
-// CDATA section will be skipped and not transliterated.
-func traverseXmlNode(node *etree.Element) {
+// CDATA section will be skipped and not transliterated. Whether the text is transliterated is inherited from the
+// enclosing element, unless the element itself has a lang or xml:lang attribute which decides it.
+func traverseXmlNode(node *etree.Element, transliterate bool) {
+	if lang := xmlLanguageAttr(node); lang != nil {
+		if allowed, decided := languageAllowsTransliteration(lang.Value); decided {
+			if transliterate && !allowed {
+				currentStats.countLangProtected()
+			}
+			transliterate = allowed
+		}
+	}
+
 	// iterates through element's Childs and transliterates only the text childs
 	// these childs are any part of the text file including new line characters, inline text fields and xml elements
 	for _, child := range node.Child {
+		if !transliterate {
+			break
+		}
 		if childData, ok := child.(*etree.CharData); ok {
 			// we ignore CDATA section
 			if childData.IsCData() {
@@ -321,26 +613,50 @@ func traverseXmlNode(node *etree.Element) {
 	}
 	// iterates through the Child elements which represent only xml elements
 	for _, childElement := range node.ChildElements() {
-		traverseXmlNode(childElement)
+		traverseXmlNode(childElement, transliterate)
+	}
+}
+
+// Returns the xml:lang attribute of the element, or the lang attribute without a namespace used by XHTML.
+func xmlLanguageAttr(node *etree.Element) *etree.Attr {
+	var lang *etree.Attr
+	for i := range node.Attr {
+		if node.Attr[i].Key != "lang" {
+			continue
+		}
+		if node.Attr[i].Space == "xml" {
+			return &node.Attr[i]
+		}
+		if node.Attr[i].Space == "" {
+			lang = &node.Attr[i]
+		}
 	}
+	return lang
 }
+
+// The language of the root element is the language of the whole document, so when it is Serbian it is set to
+// the transliterated script, like it is done for the html element of HTML documents.
+func adjustXmlRootLanguage(root *etree.Element) {
+	if root == nil || selectedDirection() == NoDirection {
+		return
+	}
+	for i := range root.Attr {
+		if root.Attr[i].Key == "lang" && (root.Attr[i].Space == "xml" || root.Attr[i].Space == "") &&
+			isSchemeLanguage(root.Attr[i].Value) {
+			root.Attr[i].Value = transliteratedLanguageTag()
+		}
+	}
+}
+
 func transliterateXmlText(line string) string {
 
 	lineprefix := dictionary.Whitepref.FindString(line)
 	linesuffix := dictionary.Whitesuff.FindString(line)
 	words := strings.Fields(line)
+	capitals := sentenceCapitals(words)
 
 	for word := range words {
-		if *dictionary.L2cPtr {
-			index := transliterationIndexOfWordStartsWith(strings.ToLower(words[word]), dictionary.WholeForeignWords, "-")
-			if index >= 0 {
-				words[word] = string(words[word][:index]) + l2c(string(words[word][index:]))
-			} else if !looksLikeForeignWord(words[word]) {
-				words[word] = l2c(words[word])
-			}
-		} else { // *c2lPtr
-			words[word] = c2l(words[word])
-		}
+		words[word] = transliterateWord(words[word], capitals[word], line)
 	}
 
 	// Preserve the whitespace at the beginning and at the end of the line
@@ -350,60 +666,123 @@ func transliterateXmlText(line string) string {
 	return line
 }
 
-func Transliterate(documents []Document) []Document {
-	if !isStdIn() {
-		fmt.Println("Пресловљавање")
+// Transliterates the documents one after another. A document which cannot be transliterated is reported and
+// skipped, and the rest are transliterated, unless -fail-fast is given.
+func Transliterate(documents []Document) Summary {
+	var summary Summary
+	if !isStdIn() && currentArchive == nil {
+		messages.Printf(messages.Transliterating)
 	}
-	for i := range documents {
-		documents[i].open()
-		documents[i].transliterate()
-		documents[i].finalize()
+	if *dictionary.DiacriticsPtr && diacriticRestorer == nil {
+		if err := loadLexicon(); err != nil {
+			return failAll(documents, err)
+		}
+	}
+	reviewStarted, err := startReview()
+	if err != nil {
+		return failAll(documents, err)
+	}
+	if reviewStarted {
+		defer finishReview()
+	}
+	reportStarted := startReport()
+
+	for _, document := range documents {
+		err := transliterateDocument(document)
+		// the documents inside an archive are counted instead of the archive
+		if zipArchive, ok := document.(*ZipArchive); ok {
+			summary.Add(zipArchive.summary)
+		} else if err == nil {
+			summary.Transliterated++
+		}
+		if err != nil {
+			summary.fail(documentPath(document), err)
+		}
+		if summary.Stopped() {
+			break
+		}
 	}
 
-	return documents
+	if reportStarted {
+		if err := finishReport(); err != nil {
+			summary.fail(*dictionary.ReportPtr, err)
+		}
+	}
+	return summary
 }
 
-func CreateDocuments() []Document {
-	documents := []Document{}
-
-	if isStdIn() {
-		documents = append(documents, &StdIn{})
-	} else {
-		for i := range terminal.InputFilenames {
-			mediaType, _ := detectFileType(terminal.InputFilePaths[i])
-
-			switch mediaType {
-			case acceptedMime["text"]:
-				documents = append(documents,
-					&TextDocument{inputFilePath: terminal.InputFilePaths[i],
-						outputFilePath: terminal.OutputFilePaths[i]})
-			case acceptedMime["html"]:
-				documents = append(documents,
-					&HtmlDocument{inputFilePath: terminal.InputFilePaths[i],
-						outputFilePath: terminal.OutputFilePaths[i]})
-			case acceptedMime["xml"], acceptedMime["xhtml"]:
-				documents = append(documents,
-					&XmlDocument{inputFilePath: terminal.InputFilePaths[i],
-						outputFilePath: terminal.OutputFilePaths[i]})
-			case acceptedMime["zip"]:
-				documents = append(documents,
-					&ZipArchive{inputFilePath: terminal.InputFilePaths[i],
-						outputFilePath: terminal.OutputFilePaths[i]})
-			default:
-				fmt.Printf("Упозорење - тип фајла %s није подржан: %s\n", mediaType, terminal.InputFilePaths[i])
+// Transliterates the document, and removes its partial output if it cannot be transliterated.
+func transliterateDocument(document Document) error {
+	if diacriticRestorer != nil {
+		diacriticRestorer.Reset()
+	}
+	if err := document.open(); err != nil {
+		document.abort()
+		return err
+	}
+	currentStats = report.startDocument(document)
+	if err := document.transliterate(); err != nil {
+		document.abort()
+		return err
+	}
+	if err := document.finalize(); err != nil {
+		document.abort()
+		return err
+	}
+	return nil
+}
 
-			}
+// Returns the summary of the documents when none of them can be transliterated because of the error.
+func failAll(documents []Document, err error) Summary {
+	var summary Summary
+	for _, document := range documents {
+		summary.fail(documentPath(document), err)
+		if summary.Stopped() {
+			break
 		}
 	}
+	return summary
+}
 
-	return documents
+// Returns the path by which the input of the document is named in the messages.
+func documentPath(document Document) string {
+	if _, ok := document.(*StdIn); ok {
+		return "-"
+	}
+	return displayPath(document.getInputFilePath())
 }
 
-func CreateZipDocuments(inputFilePaths []string, outputFilePaths []string) []Document {
+// Returns the documents for the input given with the flags, and the summary of the input files which cannot be
+// transliterated.
+func CreateDocuments() ([]Document, Summary) {
+	if isStdIn() {
+		return []Document{&StdIn{}}, Summary{}
+	}
+	return CreateZipDocuments(terminal.InputFilePaths, terminal.OutputFilePaths)
+}
+
+// Returns the documents for the input files by their types, and the summary of the files which cannot be
+// transliterated.
+func CreateZipDocuments(inputFilePaths []string, outputFilePaths []string) ([]Document, Summary) {
 	documents := []Document{}
+	var summary Summary
 
 	for i := range inputFilePaths {
-		mediaType, _ := detectFileType(inputFilePaths[i])
+		if isSkipped(inputFilePaths[i]) {
+			continue
+		}
+		mediaType, _, err := detectFileType(inputFilePaths[i])
+		if err != nil {
+			summary.fail(displayPath(inputFilePaths[i]), err)
+			if summary.Stopped() {
+				break
+			}
+			continue
+		}
+		if *dictionary.SlugPtr && mediaType != acceptedMime["text"] {
+			messages.Printf(messages.SlugTextOnly, inputFilePaths[i])
+			continue
+		}
 
 		switch mediaType {
 		case acceptedMime["text"]:
@@ -418,29 +797,100 @@ func CreateZipDocuments(inputFilePaths []string, outputFilePaths []string) []Doc
 			documents = append(documents,
 				&XmlDocument{inputFilePath: inputFilePaths[i],
 					outputFilePath: outputFilePaths[i]})
+		case acceptedMime["xliff"]:
+			documents = append(documents,
+				&XliffDocument{inputFilePath: inputFilePaths[i],
+					outputFilePath: outputFilePaths[i]})
 		case acceptedMime["zip"]:
 			documents = append(documents,
 				&ZipArchive{inputFilePath: inputFilePaths[i],
 					outputFilePath: outputFilePaths[i]})
+		case acceptedMime["csv"], acceptedMime["tsv"]:
+			documents = append(documents,
+				&CsvDocument{inputFilePath: inputFilePaths[i],
+					outputFilePath: outputFilePaths[i],
+					tsv:            mediaType == acceptedMime["tsv"]})
 		default:
-			fmt.Printf("Упозорење - тип фајла %s није подржан: %s\n", mediaType, inputFilePaths[i])
+			messages.Printf(messages.UnsupportedFileType, mediaType, inputFilePaths[i])
 
 		}
 	}
 
-	return documents
+	return documents, summary
 }
 
-func detectFileType(filePath string) (string, string) {
+func detectFileType(filePath string) (string, string, error) {
 	mimeType, err := mimetype.DetectFile(filePath)
 	if err != nil {
-		exit.ExitWithError(err, filePath)
+		return "", "", err
 	}
 
-	// converting to lower case not to worry about the case of retrieved string value
-	mediaType := strings.ToLower(mimeType.String())
+	// text in a legacy encoding is detected again once decoded, so that the markup in UTF-16 is recognized as well
+	if isTextMimeType(mimeType) {
+		encodingName := *dictionary.FromEncodingPtr
+		if encodingName == "" {
+			if encodingName, err = charset.DetectFile(filePath); err != nil {
+				return "", "", err
+			}
+		}
+		if !charset.IsUTF8(encodingName) {
+			if mimeType, err = detectDecodedFileType(filePath, encodingName); err != nil {
+				return "", "", err
+			}
+		}
+	}
+
+	// converting to lower case not to worry about the case of retrieved string value, and only the media type
+	// without the charset is considered, since the input is decoded to UTF-8 whatever encoding it has
+	mediaType, _, _ := mime.ParseMediaType(strings.ToLower(mimeType.String()))
+
+	// delimiter separated values are recognized only by commas and tabs, HTML fragments only by some of the elements,
+	// and XLIFF only by the 1.2 namespace, so the file extension decides for the rest
+	switch mediaType {
+	case acceptedMime["text"]:
+		switch strings.ToLower(filepath.Ext(filePath)) {
+		case ".csv":
+			mediaType = acceptedMime["csv"]
+		case ".tsv", ".tab":
+			mediaType = acceptedMime["tsv"]
+		case ".html", ".htm":
+			mediaType = acceptedMime["html"]
+		}
+	case acceptedMime["xml"]:
+		switch strings.ToLower(filepath.Ext(filePath)) {
+		case ".xlf", ".xliff":
+			mediaType = acceptedMime["xliff"]
+		}
+	}
+
+	return mediaType, mimeType.Extension(), nil
+}
+
+func isTextMimeType(mimeType *mimetype.MIME) bool {
+	for ; mimeType != nil; mimeType = mimeType.Parent() {
+		if strings.HasPrefix(mimeType.String(), "text/") {
+			return true
+		}
+	}
+	return false
+}
+
+func detectDecodedFileType(filePath string, encodingName string) (*mimetype.MIME, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
 
-	return mediaType, mimeType.Extension()
+	decoded, err := charset.NewReader(file, encodingName)
+	if err != nil {
+		return nil, err
+	}
+	sample, err := io.ReadAll(io.LimitReader(decoded, charset.SampleSize))
+	if err != nil {
+		return nil, err
+	}
+	return mimetype.Detect(sample), nil
 }
 
 func isStdIn() bool {
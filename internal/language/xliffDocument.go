@@ -0,0 +1,182 @@
+package language
+
+import (
+	"strings"
+
+	"github.com/beevik/etree"
+	"github.com/eevan78/translit/internal/dictionary"
+	"github.com/eevan78/translit/internal/messages"
+	"github.com/eevan78/translit/internal/terminal"
+)
+
+var (
+	// Inline elements whose own text is native code of the original document, not translatable text.
+	// Their translatable sub-flows (<sub>) are still transliterated.
+	xliffCodeElements = map[string]bool{
+		"ph":  true,
+		"bpt": true,
+		"ept": true,
+		"it":  true,
+	}
+)
+
+type XliffDocument struct {
+	inputFilePath  string
+	outputFilePath string
+	fop            *terminal.FileOperator
+}
+
+func (document *XliffDocument) open() error {
+	document.fop = &terminal.FileOperator{Markup: true}
+	return document.fop.OpenAndCreate(document.inputFilePath, document.outputFilePath)
+}
+
+// Transliterates the <target> segments of XLIFF 1.2 and 2.0 files and sets the target language to the script
+// of the transliteration. A missing or empty <target> is created from the <source> when the source language is
+// the language of the scheme, like Serbian. The <source> is transliterated only when requested with the flag.
+func (document *XliffDocument) transliterate() error {
+	xmlDocument := etree.NewDocument()
+	xmlDocument.ReadSettings = etree.ReadSettings{PreserveCData: true, CharsetReader: xmlCharsetReader}
+	if _, err := xmlDocument.ReadFrom(document.fop.Reader); err != nil {
+		return err
+	}
+
+	root := xmlDocument.Root()
+	if root == nil || root.Tag != "xliff" {
+		return messages.Errorf(messages.NotXliff)
+	}
+
+	tag := xliffLanguageTag()
+	if selectedDirection() == NoDirection && !*dictionary.DiacriticsPtr {
+		// only decoded from the given encoding
+	} else if strings.HasPrefix(root.SelectAttrValue("version", ""), "2") {
+		sourceInLanguage := isSchemeLanguage(root.SelectAttrValue("srcLang", ""))
+		root.CreateAttr("trgLang", tag)
+		if *dictionary.XliffSourcePtr && sourceInLanguage {
+			root.CreateAttr("srcLang", tag)
+		}
+		for _, file := range root.SelectElements("file") {
+			traverseXliffUnits(file, sourceInLanguage, tag)
+		}
+	} else {
+		for _, file := range root.SelectElements("file") {
+			sourceInLanguage := isSchemeLanguage(file.SelectAttrValue("source-language", ""))
+			file.CreateAttr("target-language", tag)
+			if *dictionary.XliffSourcePtr && sourceInLanguage {
+				file.CreateAttr("source-language", tag)
+			}
+			traverseXliffUnits(file, sourceInLanguage, tag)
+		}
+	}
+
+	adjustXmlDeclaration(xmlDocument)
+	if _, err := xmlDocument.WriteTo(document.fop.Writer); err != nil {
+		return err
+	}
+
+	return document.fop.Writer.Flush()
+}
+
+func (document *XliffDocument) getInputFilePath() string {
+	return document.inputFilePath
+}
+
+func (document *XliffDocument) getOuputFilePath() string {
+	return document.outputFilePath
+}
+
+func (document *XliffDocument) finalize() error {
+	if err := document.fop.Close(); err != nil {
+		return err
+	}
+	messages.Printf(messages.Success, document.inputFilePath, document.outputFilePath)
+	return nil
+}
+
+func (document *XliffDocument) abort() {
+	document.fop.Abort()
+}
+
+// Goes through the groups of a file and transliterates translation units, which are <trans-unit> in XLIFF 1.2,
+// and <segment> or <ignorable> in XLIFF 2.0. Units marked with translate="no" are skipped.
+func traverseXliffUnits(node *etree.Element, sourceInLanguage bool, tag string) {
+	for _, child := range node.ChildElements() {
+		if child.SelectAttrValue("translate", "yes") == "no" {
+			continue
+		}
+		switch child.Tag {
+		case "trans-unit", "segment", "ignorable":
+			transliterateXliffUnit(child, sourceInLanguage, tag)
+		case "source", "target", "seg-source", "alt-trans", "notes", "note":
+			// only the direct children of a unit are translatable content
+		default:
+			traverseXliffUnits(child, sourceInLanguage, tag)
+		}
+	}
+}
+
+func transliterateXliffUnit(unit *etree.Element, sourceInLanguage bool, tag string) {
+	source := unit.SelectElement("source")
+	target := unit.SelectElement("target")
+
+	if (target == nil || len(target.Child) == 0) && source != nil && sourceInLanguage {
+		created := source.Copy()
+		created.Tag = "target"
+		if target != nil {
+			// the empty target is replaced in its place, keeping its indentation
+			index := target.Index()
+			unit.RemoveChildAt(index)
+			unit.InsertChildAt(index, created)
+		} else {
+			unit.InsertChildAt(source.Index()+1, created)
+			// indent the new target the same way as the source
+			if index := source.Index(); index > 0 {
+				if indent, ok := unit.Child[index-1].(*etree.CharData); ok && allWhite(indent.Data) {
+					unit.InsertChildAt(index+1, etree.NewText(indent.Data))
+				}
+			}
+		}
+		target = created
+	}
+
+	if target != nil {
+		if target.SelectAttr("xml:lang") != nil {
+			target.CreateAttr("xml:lang", tag)
+		}
+		traverseXliffInline(target)
+	}
+
+	if source != nil && *dictionary.XliffSourcePtr {
+		if sourceInLanguage && source.SelectAttr("xml:lang") != nil {
+			source.CreateAttr("xml:lang", tag)
+		}
+		traverseXliffInline(source)
+	}
+}
+
+// Transliterates the text of a segment and of its inline elements, leaving the native codes and the content
+// marked as protected as they are.
+func traverseXliffInline(node *etree.Element) {
+	if node.SelectAttrValue("translate", "yes") == "no" || node.SelectAttrValue("mtype", "") == "protected" {
+		return
+	}
+	if !xliffCodeElements[node.Tag] {
+		for _, child := range node.Child {
+			if childData, ok := child.(*etree.CharData); ok && !childData.IsCData() && !allWhite(childData.Data) {
+				childData.Data = transliterateText(childData.Data)
+			}
+		}
+	}
+	for _, childElement := range node.ChildElements() {
+		traverseXliffInline(childElement)
+	}
+}
+
+// Returns the BCP 47 language tag of the transliterated text, the same as in HTML and XML.
+func xliffLanguageTag() string {
+	if selectedDirection() == NoDirection {
+		// the diacritics are only restored in the Latin text
+		return dictionary.CurrentScheme().Languages[0] + "-Latn"
+	}
+	return transliteratedLanguageTag()
+}
@@ -1,10 +1,8 @@
 package language
 
 import (
-	"fmt"
-
 	"github.com/beevik/etree"
-	"github.com/eevan78/translit/internal/exit"
+	"github.com/eevan78/translit/internal/messages"
 	"github.com/eevan78/translit/internal/terminal"
 )
 
@@ -14,23 +12,26 @@ type XmlDocument struct {
 	fop            *terminal.FileOperator
 }
 
-func (document *XmlDocument) open() {
-	document.fop = &terminal.FileOperator{}
-	document.fop.Open(document.inputFilePath)
-	document.fop.Create(document.outputFilePath)
+func (document *XmlDocument) open() error {
+	document.fop = &terminal.FileOperator{Markup: true}
+	return document.fop.OpenAndCreate(document.inputFilePath, document.outputFilePath)
 }
 
-func (document *XmlDocument) transliterate() {
+func (document *XmlDocument) transliterate() error {
 	xmlDocument := etree.NewDocument()
 	// do not consider CDATA section as XML element so we can differentiate them during transliteration.
-	xmlDocument.ReadSettings = etree.ReadSettings{PreserveCData: true}
+	xmlDocument.ReadSettings = etree.ReadSettings{PreserveCData: true, CharsetReader: xmlCharsetReader}
 	if _, err := xmlDocument.ReadFrom(document.fop.Reader); err != nil {
-		exit.ExitWithError(err, document.getInputFilePath())
+		return err
+	}
+	adjustXmlRootLanguage(xmlDocument.Root())
+	traverseXmlNode(&xmlDocument.Element, true)
+	adjustXmlDeclaration(xmlDocument)
+	if _, err := xmlDocument.WriteTo(document.fop.Writer); err != nil {
+		return err
 	}
-	traverseXmlNode(&xmlDocument.Element)
-	xmlDocument.WriteTo(document.fop.Writer)
 
-	_ = document.fop.Writer.Flush()
+	return document.fop.Writer.Flush()
 }
 
 func (document *XmlDocument) getInputFilePath() string {
@@ -41,7 +42,14 @@ func (document *XmlDocument) getOuputFilePath() string {
 	return document.outputFilePath
 }
 
-func (document *XmlDocument) finalize() {
-	fmt.Printf("Успешно: %s \nу %s\n", document.inputFilePath, document.outputFilePath)
-	document.fop.Close()
+func (document *XmlDocument) finalize() error {
+	if err := document.fop.Close(); err != nil {
+		return err
+	}
+	messages.Printf(messages.Success, document.inputFilePath, document.outputFilePath)
+	return nil
+}
+
+func (document *XmlDocument) abort() {
+	document.fop.Abort()
 }
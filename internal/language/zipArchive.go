@@ -1,11 +1,11 @@
 package language
 
 import (
-	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/eevan78/translit/internal/archive"
-	"github.com/eevan78/translit/internal/exit"
+	"github.com/eevan78/translit/internal/messages"
 	"github.com/eevan78/translit/internal/terminal"
 )
 
@@ -13,20 +13,51 @@ type ZipArchive struct {
 	inputFilePath  string
 	outputFilePath string
 	innerDocuments []Document
-	translitDir    string // where to place transliterated files
-	unzipDir       string // where to place unzipped files
+	summary        Summary // of the documents inside the archive
+	translitDir    string  // where to place transliterated files
+	unzipDir       string  // where to place unzipped files
 }
 
-func (document *ZipArchive) open() {
-	document.unzipDir, document.translitDir = terminal.PrepareZipDirectories(document.inputFilePath)
-	archive.Unzip(document.inputFilePath, document.unzipDir)
-	inputFilePaths := terminal.PrepareInputDirectoryForZip(document.unzipDir)
-	outputFilePaths := terminal.PrepareOutputDirectoryForZip(document.unzipDir, inputFilePaths, document.translitDir)
-	document.innerDocuments = CreateZipDocuments(inputFilePaths, outputFilePaths)
+// archive whose documents are being transliterated
+var currentArchive *ZipArchive
+
+func (document *ZipArchive) open() error {
+	var err error
+	document.unzipDir, document.translitDir, err = terminal.PrepareZipDirectories(document.inputFilePath)
+	if err != nil {
+		return err
+	}
+	if err = archive.Unzip(document.inputFilePath, document.unzipDir); err != nil {
+		return err
+	}
+	inputFilePaths, err := terminal.PrepareInputDirectoryForZip(document.unzipDir)
+	if err != nil {
+		return err
+	}
+	outputFilePaths, err := terminal.PrepareOutputDirectoryForZip(document.unzipDir, inputFilePaths, document.translitDir)
+	if err != nil {
+		return err
+	}
+	defer document.enter()()
+	document.innerDocuments, document.summary = CreateZipDocuments(inputFilePaths, outputFilePaths)
+	return nil
 }
 
-func (document *ZipArchive) transliterate() {
-	Transliterate(document.innerDocuments)
+func (document *ZipArchive) transliterate() error {
+	if document.summary.Stopped() {
+		return nil
+	}
+	defer document.enter()()
+	document.summary.Add(Transliterate(document.innerDocuments))
+	return nil
+}
+
+// Makes the archive the current one, so that its documents are named by their paths inside it. Returns
+// the function which restores the enclosing archive.
+func (document *ZipArchive) enter() func() {
+	enclosingArchive := currentArchive
+	currentArchive = document
+	return func() { currentArchive = enclosingArchive }
 }
 
 func (document *ZipArchive) getInputFilePath() string {
@@ -37,16 +68,45 @@ func (document *ZipArchive) getOuputFilePath() string {
 	return document.outputFilePath
 }
 
-func (document *ZipArchive) finalize() {
+func (document *ZipArchive) finalize() error {
 	inputDir := document.translitDir
 	transliteratedFiles, _ := os.ReadDir(inputDir)
 
-	if len(transliteratedFiles) > 0 {
-		if err := archive.Zip(inputDir, document.outputFilePath); err != nil {
-			exit.ExitWithError(err, document.inputFilePath)
+	if len(transliteratedFiles) == 0 {
+		return messages.Errorf(messages.NothingInArchive)
+	}
+	if err := archive.Zip(inputDir, document.outputFilePath); err != nil {
+		return err
+	}
+	messages.Printf(messages.Success, document.inputFilePath, document.outputFilePath)
+	return nil
+}
+
+// Removes the temporary directories of the unzipped and the transliterated files.
+func (document *ZipArchive) abort() {
+	for _, dir := range []string{document.unzipDir, document.translitDir} {
+		if dir != "" {
+			os.RemoveAll(dir)
 		}
-		fmt.Printf("Успешно: %s \nу %s\n", document.inputFilePath, document.outputFilePath)
-	} else {
-		fmt.Println("Неуспешно: ниједан фајл у улазној zip архиви није успешно пресловљен.", document.inputFilePath)
 	}
 }
+
+// Returns the path of a file unzipped from the archive, or transliterated into it, as the path of the archive
+// followed by the path of the file inside it.
+func (document *ZipArchive) memberPath(path string) string {
+	if rel, err := filepath.Rel(document.unzipDir, path); err == nil && filepath.IsLocal(rel) {
+		return filepath.Join(document.inputFilePath, rel)
+	}
+	if rel, err := filepath.Rel(document.translitDir, path); err == nil && filepath.IsLocal(rel) {
+		return filepath.Join(document.outputFilePath, rel)
+	}
+	return path
+}
+
+// Returns the path by which the file is named in the messages and in the report.
+func displayPath(path string) string {
+	if currentArchive != nil {
+		return currentArchive.memberPath(path)
+	}
+	return path
+}
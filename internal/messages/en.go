@@ -0,0 +1,151 @@
+package messages
+
+// Messages in English
+var english = map[Key]string{
+	HelpHeader:   "This is the filter %s version %s\nWritten by eevan78, 2024-%v\n\n",
+	HelpIntro:    "The filter reads UTF-8 encoded text from the standard input or from the given file and writes it to\nthe standard output or to the output file, transliterated according to the following flags:\n",
+	HelpDetails:  "\nWhen -c, -config or -profile is given, the program is set up by reading the configuration, and the\nother given flags override its settings. Otherwise, exactly one flag from each of the groups Direction and Format\nhas to be given. When the flag for the input file is given, only the flag of the direction is needed.\nWhole words between „<|” and „|>” in plain text are not transliterated.\nText inside the <span lang=\"sr-Latn\"></span> element in (X)HTML is not transliterated to Cyrillic,\nand text inside <span lang=\"sr-Cyrl\"></span> is not transliterated to Latin.\n\nExamples:\n%[1]s -l2c -html\t\ttransliterate (X)HTML to Cyrillic\n%[1]s -text -c2l\t\ttransliterate plain text to Latin\n%[1]s -text -slug\t\tmake a slug of every line of plain text\n%[1]s -c\t\t\tthe program reads the settings from the configuration file\n",
+	HelpHint:     "Help is shown with %s -h\n",
+	HelpCommands: "\nCommands, with long flags (--l2c, --input), instead of the flags without a command:\n  convert\ttransliterates text and files\n  check\t\tchecks whether text is already transliterated\n  serve\t\tstarts an HTTP server for transliteration\n  dict\t\tshows and changes the user dictionary\n  config\tchecks and shows the configuration\nHelp about a command is shown with %[1]s <command> --help\n",
+
+	CommandHelpHint:    "Help about the command is shown with %s %s --help\n",
+	CommandUsage:       "Usage: %s %s %s\n\n%s\n\nFlags:\n",
+	ConvertSynopsis:    "[flags] [input]",
+	ConvertDescription: "Transliterates the standard input or the input file, directory or zip archive, like the flags without a command.\nThe standard input is plain text, unless --html is given.",
+	CheckSynopsis:      "[flags] [file...]",
+	CheckDescription:   "Checks whether plain text from the files or from the standard input is already transliterated. Writes every word\nthe transliteration would change, with the file, line and rule, and then exits with status 4.",
+	ServeSynopsis:      "[flags]",
+	ServeDescription:   "Starts an HTTP server which transliterates text sent with POST to /l2c, /c2l or /c2a and returns it as plain text.\nThe scheme is selected with the scheme parameter, and the scheme from --scheme is the default.",
+	DictSynopsis:       "[flags] list | set word decision | remove word",
+	DictDescription:    "Shows and changes the user dictionary from --user-dict: list writes the words and decisions, set writes the decision\naccept, convert or protect for a word, and remove deletes a word from the dictionary.",
+	ConfigSynopsis:     "[flags] validate | show",
+	ConfigDescription:  "Works with the configuration file from --config or found like with -c: validate checks the names, types and\ncombinations of the settings and the version of the file, and show writes the settings which are used, with the profile\nfrom --profile and the environment variables, in the form of the latest version.",
+
+	"flag-l2c":           "Transliteration `direction` is Latin to Cyrillic",
+	"flag-c2l":           "Transliteration `direction` is Cyrillic to Latin",
+	"flag-c2a":           "Transliteration `direction` is Cyrillic or Latin to Latin without diacritics (ASCII)",
+	"flag-slug":          "Every line of plain text is turned into a slug for web addresses and file names (ASCII lowercase letters, digits and hyphens)",
+	"flag-html":          "Input `format` is (X)HTML",
+	"flag-text":          "Input `format` is plain text",
+	"flag-c":             "The configuration from .translit.yaml, $XDG_CONFIG_HOME/translit/config.yaml or /etc/translit/config.yaml is used, overridden by the TRANSLIT_ environment variables",
+	"flag-profile":       "`Name` of the profile from the configuration file whose settings are used, overridden by the flags given with the command",
+	"flag-skip":          "`Patterns` of the names of the files in a directory or zip archive which are not transliterated (e.g. *.css,toc.ncx)",
+	"flag-config":        "Path of the configuration `file` which is used instead of the file found with -c",
+	"flag-i":             "Path of the input file or directory",
+	"flag-columns":       "`Columns` of the CSV/TSV file which are transliterated, by the name from the header or by the number from 1 (e.g. naziv,opis or 2,3)",
+	"flag-header":        "The first line of the CSV/TSV file is the header and is not transliterated",
+	"flag-attrs":         "`Attributes` of the (X)HTML elements which are transliterated (value only on buttons)",
+	"flag-meta":          "`Names` of the meta elements whose content is transliterated",
+	"flag-fragment":      "The input is a part of an (X)HTML document, without the html, head and body elements (also recognized by itself)",
+	"flag-nolang":        "The lang attribute of the html element is neither changed nor added",
+	"flag-stream":        "(X)HTML is transliterated while it is read, keeping the original markup",
+	"flag-from-encoding": "`Encoding` of the input, e.g. windows-1250, windows-1251, iso-8859-2, iso-8859-5, koi8-r, utf-16, yuscii-latin or yuscii-cyrillic (detected when not given, except YUSCII)",
+	"flag-to-encoding":   "`Encoding` of the output (UTF-8 by default)",
+	"flag-diacritics":    "Diacritics are restored in Latin text typed without them (e.g. zivot to život), also before the transliteration to Cyrillic",
+	"flag-lexicon":       "Path of the word frequency `lexicon` for restoring diacritics (the built-in one by default)",
+	"flag-scheme":        "Transliteration `scheme`: sr (Serbian Cyrillic and Latin), cnr (Montenegrin, with Ś, Ź, С́ and З́), mk (Macedonian, with Ѓ, Ќ and Ѕ), or to Latin only iso9 (ISO 9), bgn (BGN/PCGN) and icao (ICAO Doc 9303)",
+	"flag-scheme-file":   "Path of the YAML or JSON `file` with a transliteration scheme, which is used instead of the scheme from -scheme",
+	"flag-dump-scheme":   "Writes the scheme selected with -scheme in the yaml or json `format`, as an example for -scheme-file",
+	"flag-report":        "Path of the JSON `file` the transliteration report is written to: the number of transliterated words, foreign words, units and protected parts per document",
+	"flag-explain":       "For every word, the rule which decided its transliteration is written to the standard error",
+	"flag-interactive":   "When transliterating to Cyrillic, for every foreign word and word with a split digraph the user is asked whether to accept the decision of the rules, to transliterate the word or to leave it intact",
+	"flag-user-dict":     "Path of the user dictionary `file` the decisions from -interactive are written to and read from in later runs",
+	"flag-fail-fast":     "The transliteration stops at the first file which cannot be transliterated, instead of going on with the rest",
+	"flag-xliff-source":  "In XLIFF files <source> is transliterated as well, not only <target>",
+	"flag-addr":          "`Address` the transliteration server listens on",
+	"flag-ui-lang":       "`Language` of the messages of the program: sr-Cyrl, sr-Latn or en (by default the language from LC_ALL, LC_MESSAGES or LANG)",
+
+	UsageError:    "wrong use of the flags",
+	Error:         "Error: %v\n",
+	ErrorWithFile: "Error with:  %s %v\n",
+
+	ConflictingFlags:         "%w: %s and %s cannot be given together",
+	MissingDirection:         "%w: the transliteration direction is missing, give %s, %s or %s",
+	FlagRequires:             "%w: %s can be given only with %s",
+	FormatWithInput:          "%w: %s cannot be given with an input file, since the format of the file is detected",
+	MissingFormat:            "%w: the format has to be given for the standard input, %s or %s",
+	BadSkipPattern:           "%w: invalid pattern %q in %s",
+	UnknownCommand:           "%w: unknown command %s, possible are: %s",
+	UnexpectedArguments:      "%w: unexpected arguments: %s",
+	InputGivenTwice:          "%w: the input is given both with %s and as an argument",
+	MissingAction:            "%w: the action is missing, possible are: %s",
+	UnknownAction:            "%w: unknown action %s, possible are: %s",
+	MissingArguments:         "%w: the arguments of the action %s are missing",
+	CommandRequires:          "%w: the command %s requires the flag %s",
+	UnknownDecision:          "%w: unknown decision %q, possible are accept, convert and protect",
+	WordNotInDictionary:      "word %s is not in the user dictionary",
+	UnknownDirection:         "unknown direction %s, possible are l2c, c2l and c2a",
+	OnlyPost:                 "text is sent only with POST",
+	TextTooLarge:             "text is longer than %d bytes",
+	UnknownSchemeParameter:   "unknown scheme %s in the scheme parameter, possible are: %s",
+	LatinOnlySchemeParameter: "scheme %s from the scheme parameter is only for the transliteration to Latin",
+	Listening:                "The transliteration server listens on http://%s\n",
+	WordsToChange:            "The transliteration would change %d words\n",
+
+	UnknownUiLanguage:    "unknown language of the messages %s, possible are: %s",
+	UnknownScheme:        "%w: unknown scheme %s, possible are: %s",
+	LatinOnlyScheme:      "%w: scheme %s is only for the transliteration to Latin",
+	UrlTrailingSlash:     "a URL ending with / is currently not allowed",
+	ConfigReadError:      "error reading the configuration file %s: %w",
+	ConfigError:          "error in the configuration file %s: %w",
+	ConfigEnvError:       "error in the TRANSLIT_ environment variables: %w",
+	ConfigMissing:        "configuration file %s does not exist",
+	ConfigNotFound:       "no configuration file is found, searched as %s",
+	UnknownProfile:       "profile %s does not exist in the configuration file %s, there are: %s",
+	ConfigNotMapping:     "%s has to hold settings with their values",
+	ConfigUnknownKey:     "unknown setting %s",
+	ConfigConflict:       "settings %s and %s cannot both be true",
+	ConfigNotBool:        "setting %s has to be true or false",
+	ConfigNotString:      "setting %s has to be text",
+	ConfigUnknownVersion: "unknown version %s of the configuration file, known are: %s",
+	ConfigMigrated:       "Warning - configuration file %s is of version %s and is migrated to %s, and the command config show writes the settings in the new form\n",
+	ConfigValid:          "Configuration file %s of version %s is valid\n",
+
+	Transliterating:     "Transliterating\n",
+	Success:             "Done: %s \nto %s\n",
+	Archiving:           "Archiving\nto %s\n",
+	ReportWritten:       "Report: %s\n",
+	FailureSummary:      "\n%d of %d files are not transliterated:\n",
+	SlugTextOnly:        "Warning - a slug is made only of plain text: %s\n",
+	UnsupportedFileType: "Warning - file type %s is not supported: %s\n",
+	MissingColumn:       "Warning - column %s does not exist in the header: %s\n",
+	AmbiguousWord:       "Warning - word %s can be: %s\n",
+	DecisionNotSaved:    "Warning - the decision is not saved to %s: %v\n",
+	ReviewPrompt:        "[a]ccept, [c]onvert or protect [z] (a/c/z)? ",
+	AnswerNotRead:       "\nWarning - the answer is not read (%v), the decision of the rules is accepted\n",
+	NotXliff:            "the root element is not xliff",
+	NothingInArchive:    "no file in the input zip archive is transliterated",
+	UnterminatedQuote:   "line %d: the closing quote is missing",
+
+	RuleTransliterated:                     "transliterated",
+	RuleSerbianWordWithForeignCombinations: "transliterated, Serbian word with foreign letter combinations (%s)",
+	RuleForeignCharacterCombinations:       "not transliterated, foreign letter combination (%s)",
+	RuleCommonForeignWords:                 "not transliterated, common foreign word (%s)",
+	RuleWholeForeignWords:                  "not transliterated, whole foreign word (%s)",
+	RuleForeignPrefix:                      "transliterated after the foreign prefix with a hyphen (%s)",
+	RuleUnit:                               "not transliterated, unit of measurement (%s)",
+	RuleDigraphException:                   "transliterated, digraph exception (%s)",
+	SplitDigraph:                           ", written as %s",
+
+	UnknownEncoding:      "unknown encoding %q",
+	InputOnlyEncoding:    "encoding %q can be used only for the input",
+	UnencodableCharacter: "character %q does not exist in encoding %q",
+
+	LexiconWordExpected:   "line %d: a word or a pair of words and the frequency are expected",
+	LexiconBadFrequency:   "line %d: frequency %q is not a positive integer",
+	UserDictWordExpected:  "line %d: a word and the decision are expected",
+	UserDictBadDecision:   "line %d: unknown decision %q, possible are accept, convert and protect",
+	InvalidScheme:         "invalid scheme: %w",
+	SchemeWithoutName:     "the scheme has no name",
+	SchemeExists:          "scheme %s already exists",
+	SchemeWithoutLanguage: "scheme %s has no languages",
+	SchemeWithoutC2l:      "scheme %s has no transliteration to Latin (c2l)",
+	DigraphNotInC2l:       "digraph %s is none of the Latin letters from c2l",
+	DigraphNotInL2c:       "split digraph %s is not in l2c",
+	DigraphWithoutSplits:  "digraph %s has no words or replacements for splitting",
+	InvalidSplit:          "invalid replacement %s in %s for splitting digraph %s",
+	EmptyKey:              "empty key in %s",
+	EmptyValue:            "empty value for %s in %s",
+	SchemeConflict:        "conflict in %s: %s is transliterated both to %s and to %s",
+	UnknownSchemeFormat:   "unknown scheme format %s, possible are yaml and json",
+}
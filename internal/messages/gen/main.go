@@ -0,0 +1,49 @@
+// Command gen writes the messages in Serbian Latin, transliterated from the messages in Serbian Cyrillic by
+// the program itself. It is run with go generate in the directory of the messages.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"slices"
+
+	"github.com/eevan78/translit/internal/dictionary"
+	"github.com/eevan78/translit/internal/language"
+	"github.com/eevan78/translit/internal/messages"
+)
+
+const outputFile = "sr_latn.go"
+
+func main() {
+	cyrillic := messages.Catalog(messages.SerbianCyrillic)
+	keys := make([]messages.Key, 0, len(cyrillic))
+	for key := range cyrillic {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var source bytes.Buffer
+	source.WriteString("// Code generated by go run ./gen; DO NOT EDIT.\n\npackage messages\n\n")
+	source.WriteString("// Messages in Serbian Latin, transliterated from the messages in Serbian Cyrillic\n")
+	source.WriteString("var latin = map[Key]string{\n")
+	for _, key := range keys {
+		fmt.Fprintf(&source, "\t%q: %q,\n", key, latin(cyrillic[key]))
+	}
+	source.WriteString("}\n")
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = os.WriteFile(outputFile, formatted, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Transliterates the message to Serbian Latin.
+func latin(message string) string {
+	return language.TransliterateText(message, language.CyrillicToLatin, dictionary.Schemes["sr"])
+}
@@ -0,0 +1,134 @@
+// Package messages holds the messages of the program in Serbian Cyrillic, Serbian Latin and English. The messages
+// in Serbian Latin are generated from the Cyrillic ones by the transliteration of the program itself.
+package messages
+
+//go:generate go run ./gen
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Key names a message in the catalogs.
+type Key string
+
+// Languages of the messages
+const (
+	SerbianCyrillic = "sr-Cyrl"
+	SerbianLatin    = "sr-Latn"
+	English         = "en"
+)
+
+var (
+	catalogs = map[string]map[Key]string{
+		SerbianCyrillic: cyrillic,
+		SerbianLatin:    latin,
+		English:         english,
+	}
+	// the messages are in Serbian Cyrillic until a language is selected, like for the users of the library
+	current = SerbianCyrillic
+)
+
+// Returns the languages of the messages.
+func Languages() []string {
+	return []string{SerbianCyrillic, SerbianLatin, English}
+}
+
+// Returns the language of the messages.
+func Language() string {
+	return current
+}
+
+// Selects the language of the messages by its tag, like sr-Latn, or by a locale, like sr_RS.UTF-8@latin. Without
+// the tag, the language is taken from the LC_ALL, LC_MESSAGES or LANG environment variable, and the messages are
+// in English for any other language than Serbian.
+func Select(tag string) error {
+	if tag != "" {
+		language, ok := parseTag(tag)
+		if !ok {
+			return Errorf(UnknownUiLanguage, tag, strings.Join(Languages(), ", "))
+		}
+		current = language
+		return nil
+	}
+	current = fromEnvironment()
+	return nil
+}
+
+// Returns the language of the locale set in the environment, in the order of precedence of the variables.
+func fromEnvironment() string {
+	for _, variable := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		locale := os.Getenv(variable)
+		if locale == "" {
+			continue
+		}
+		// the default locale does not tell the language of the user
+		if locale == "C" || locale == "POSIX" || strings.HasPrefix(locale, "C.") {
+			return SerbianCyrillic
+		}
+		if language, ok := parseTag(locale); ok {
+			return language
+		}
+		return English
+	}
+	return SerbianCyrillic
+}
+
+// Returns the language of the tag or the locale, if it is one of the languages of the messages.
+func parseTag(tag string) (string, bool) {
+	tag = strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	switch {
+	case tag == "en" || strings.HasPrefix(tag, "en-") || strings.HasPrefix(tag, "en."):
+		return English, true
+	case tag == "sr" || strings.HasPrefix(tag, "sr-") || strings.HasPrefix(tag, "sr.") || strings.HasPrefix(tag, "sr@"):
+		if strings.Contains(tag, "latn") || strings.HasSuffix(tag, "@latin") {
+			return SerbianLatin, true
+		}
+		return SerbianCyrillic, true
+	}
+	return "", false
+}
+
+// Returns the message in the selected language, or in Serbian Cyrillic if it is not translated.
+func Text(key Key) string {
+	if message, ok := catalogs[current][key]; ok {
+		return message
+	}
+	return cyrillic[key]
+}
+
+// Formats the message in the selected language.
+func Sprintf(key Key, args ...any) string {
+	return fmt.Sprintf(Text(key), args...)
+}
+
+// Returns the error with the message in the selected language, which wraps the errors given for %w.
+func Errorf(key Key, args ...any) error {
+	return fmt.Errorf(Text(key), args...)
+}
+
+// Writes the message in the selected language to the standard output.
+func Printf(key Key, args ...any) {
+	fmt.Printf(Text(key), args...)
+}
+
+// Writes the message in the selected language to the writer.
+func Fprintf(writer io.Writer, key Key, args ...any) {
+	fmt.Fprintf(writer, Text(key), args...)
+}
+
+// Returns the help of the flag with the name in the selected language, or an empty string for an unknown flag.
+func FlagUsage(name string) string {
+	return Text(Key("flag-" + name))
+}
+
+// Returns a copy of the messages in the language, for the tools which generate and check the catalogs.
+func Catalog(language string) map[Key]string {
+	catalog := map[Key]string{}
+	for key, message := range catalogs[language] {
+		catalog[key] = message
+	}
+	return catalog
+}
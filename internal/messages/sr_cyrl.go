@@ -0,0 +1,267 @@
+package messages
+
+// Keys of the messages, except the help of the flags, which is found by the name of the flag
+const (
+	HelpHeader   Key = "help-header"
+	HelpIntro    Key = "help-intro"
+	HelpDetails  Key = "help-details"
+	HelpCommands Key = "help-commands"
+	HelpHint     Key = "help-hint"
+
+	CommandUsage       Key = "command-usage"
+	CommandHelpHint    Key = "command-help-hint"
+	ConvertSynopsis    Key = "convert-synopsis"
+	ConvertDescription Key = "convert-description"
+	CheckSynopsis      Key = "check-synopsis"
+	CheckDescription   Key = "check-description"
+	ServeSynopsis      Key = "serve-synopsis"
+	ServeDescription   Key = "serve-description"
+	DictSynopsis       Key = "dict-synopsis"
+	DictDescription    Key = "dict-description"
+	ConfigSynopsis     Key = "config-synopsis"
+	ConfigDescription  Key = "config-description"
+
+	UsageError    Key = "usage-error"
+	Error         Key = "error"
+	ErrorWithFile Key = "error-with-file"
+
+	ConflictingFlags         Key = "conflicting-flags"
+	MissingDirection         Key = "missing-direction"
+	FlagRequires             Key = "flag-requires"
+	FormatWithInput          Key = "format-with-input"
+	MissingFormat            Key = "missing-format"
+	BadSkipPattern           Key = "bad-skip-pattern"
+	UnknownCommand           Key = "unknown-command"
+	UnexpectedArguments      Key = "unexpected-arguments"
+	InputGivenTwice          Key = "input-given-twice"
+	MissingAction            Key = "missing-action"
+	UnknownAction            Key = "unknown-action"
+	MissingArguments         Key = "missing-arguments"
+	CommandRequires          Key = "command-requires"
+	UnknownDecision          Key = "unknown-decision"
+	WordNotInDictionary      Key = "word-not-in-dictionary"
+	UnknownDirection         Key = "unknown-direction"
+	OnlyPost                 Key = "only-post"
+	TextTooLarge             Key = "text-too-large"
+	UnknownSchemeParameter   Key = "unknown-scheme-parameter"
+	LatinOnlySchemeParameter Key = "latin-only-scheme-parameter"
+	Listening                Key = "listening"
+	WordsToChange            Key = "words-to-change"
+
+	UnknownUiLanguage    Key = "unknown-ui-language"
+	UnknownScheme        Key = "unknown-scheme"
+	LatinOnlyScheme      Key = "latin-only-scheme"
+	UrlTrailingSlash     Key = "url-trailing-slash"
+	ConfigReadError      Key = "config-read-error"
+	ConfigError          Key = "config-error"
+	ConfigEnvError       Key = "config-env-error"
+	ConfigMissing        Key = "config-missing"
+	ConfigNotFound       Key = "config-not-found"
+	UnknownProfile       Key = "unknown-profile"
+	ConfigNotMapping     Key = "config-not-mapping"
+	ConfigUnknownKey     Key = "config-unknown-key"
+	ConfigConflict       Key = "config-conflict"
+	ConfigNotBool        Key = "config-not-bool"
+	ConfigNotString      Key = "config-not-string"
+	ConfigUnknownVersion Key = "config-unknown-version"
+	ConfigMigrated       Key = "config-migrated"
+	ConfigValid          Key = "config-valid"
+
+	Transliterating     Key = "transliterating"
+	Success             Key = "success"
+	Archiving           Key = "archiving"
+	ReportWritten       Key = "report-written"
+	FailureSummary      Key = "failure-summary"
+	SlugTextOnly        Key = "slug-text-only"
+	UnsupportedFileType Key = "unsupported-file-type"
+	MissingColumn       Key = "missing-column"
+	AmbiguousWord       Key = "ambiguous-word"
+	DecisionNotSaved    Key = "decision-not-saved"
+	ReviewPrompt        Key = "review-prompt"
+	AnswerNotRead       Key = "answer-not-read"
+	NotXliff            Key = "not-xliff"
+	NothingInArchive    Key = "nothing-in-archive"
+	UnterminatedQuote   Key = "unterminated-quote"
+
+	RuleTransliterated                     Key = "rule-transliterated"
+	RuleSerbianWordWithForeignCombinations Key = "rule-serbian-word-with-foreign-combinations"
+	RuleForeignCharacterCombinations       Key = "rule-foreign-character-combinations"
+	RuleCommonForeignWords                 Key = "rule-common-foreign-words"
+	RuleWholeForeignWords                  Key = "rule-whole-foreign-words"
+	RuleForeignPrefix                      Key = "rule-foreign-prefix"
+	RuleUnit                               Key = "rule-unit"
+	RuleDigraphException                   Key = "rule-digraph-exception"
+	SplitDigraph                           Key = "split-digraph"
+
+	UnknownEncoding      Key = "unknown-encoding"
+	InputOnlyEncoding    Key = "input-only-encoding"
+	UnencodableCharacter Key = "unencodable-character"
+
+	LexiconWordExpected   Key = "lexicon-word-expected"
+	LexiconBadFrequency   Key = "lexicon-bad-frequency"
+	UserDictWordExpected  Key = "user-dict-word-expected"
+	UserDictBadDecision   Key = "user-dict-bad-decision"
+	InvalidScheme         Key = "invalid-scheme"
+	SchemeWithoutName     Key = "scheme-without-name"
+	SchemeExists          Key = "scheme-exists"
+	SchemeWithoutLanguage Key = "scheme-without-language"
+	SchemeWithoutC2l      Key = "scheme-without-c2l"
+	DigraphNotInC2l       Key = "digraph-not-in-c2l"
+	DigraphNotInL2c       Key = "digraph-not-in-l2c"
+	DigraphWithoutSplits  Key = "digraph-without-splits"
+	InvalidSplit          Key = "invalid-split"
+	EmptyKey              Key = "empty-key"
+	EmptyValue            Key = "empty-value"
+	SchemeConflict        Key = "scheme-conflict"
+	UnknownSchemeFormat   Key = "unknown-scheme-format"
+)
+
+// Messages in Serbian Cyrillic, from which the messages in Serbian Latin are generated
+var cyrillic = map[Key]string{
+	HelpHeader:   "Ово је филтер %s верзија %s\nСаставио eevan78, 2024-%v\n\n",
+	HelpIntro:    "Филтер чита UTF-8 кодирани текст са стандардног улаза или из наведеног фајла и исписује га на\nстандардни излаз или у излазни фајл, пресловљен сагласно са следећим заставицама:\n",
+	HelpDetails:  "\nКада се наведе -c, -config или -profile, програм се подешава читањем конфигурације, а остале\nнаведене заставице мењају њена подешавања. У супротном, мора да се наведе по једна и само једна заставица из обе групе\nСмер и Формат. Када се наведе заставица за улазни фајл потребно је да се наведе само заставица смера.\nЦеле речи између „<|” и „|>” у простом тексту се не пресловљавају.\nТекст унутар <span lang=\"sr-Latn\"></span> елемента у (X)HTML се не пресловљава у ћирилицу,\nа текст унутар <span lang=\"sr-Cyrl\"></span> се не пресловљава у латиницу.\n\nПримери:\n%[1]s -l2c -html\t\tпреслови (X)HTML у ћирилицу\n%[1]s -text -c2l\t\tпреслови прости текст у латиницу\n%[1]s -text -slug\t\tнаправи slug од сваког реда простог текста\n%[1]s -c\t\t\tпрограм чита подешавања из фајла конфигурације\n",
+	HelpHint:     "Помоћ се добија са %s -h\n",
+	HelpCommands: "\nНаредбе, са дугим заставицама (--l2c, --input), уместо заставица без наредбе:\n  convert\tпресловљава текст и фајлове\n  check\t\tпроверава да ли је текст већ пресловљен\n  serve\t\tпокреће HTTP сервер за пресловљавање\n  dict\t\tприказује и мења кориснички речник\n  config\tпроверава и приказује конфигурацију\nПомоћ о наредби се добија са %[1]s <наредба> --help\n",
+
+	CommandHelpHint:    "Помоћ о наредби се добија са %s %s --help\n",
+	CommandUsage:       "Употреба: %s %s %s\n\n%s\n\nЗаставице:\n",
+	ConvertSynopsis:    "[заставице] [улаз]",
+	ConvertDescription: "Пресловљава стандардни улаз или улазни фајл, директоријум или zip архиву, као и заставице без наредбе. Стандардни\nулаз је прости текст, осим када се наведе --html.",
+	CheckSynopsis:      "[заставице] [фајл...]",
+	CheckDescription:   "Проверава да ли је прости текст из фајлова или са стандардног улаза већ пресловљен. Исписује сваку реч коју би\nпресловљавање променило, са фајлом, редом и правилом, и тада излази са статусом 4.",
+	ServeSynopsis:      "[заставице]",
+	ServeDescription:   "Покреће HTTP сервер који пресловљава текст послат са POST на /l2c, /c2l или /c2a и враћа га као прости текст.\nШема се бира параметром scheme, а подразумева се шема из --scheme.",
+	DictSynopsis:       "[заставице] list | set реч одлука | remove реч",
+	DictDescription:    "Приказује и мења кориснички речник из --user-dict: list исписује речи и одлуке, set уписује одлуку accept,\nconvert или protect за реч, а remove брише реч из речника.",
+	ConfigSynopsis:     "[заставице] validate | show",
+	ConfigDescription:  "Ради са конфигурационим фајлом из --config или нађеним као са -c: validate проверава имена, врсте и комбинације\nподешавања и верзију фајла, а show исписује подешавања која се користе, са профилом из --profile и променљивама\nокружења, у облику најновије верзије.",
+
+	"flag-l2c":           "`Смер` пресловљавања је латиница у ћирилицу",
+	"flag-c2l":           "`Смер` пресловљавања је ћирилица у латиницу",
+	"flag-c2a":           "`Смер` пресловљавања је ћирилица или латиница у латиницу без дијакритичких знакова (ASCII)",
+	"flag-slug":          "Сваки ред простог текста се претвара у slug за веб адресе и имена фајлова (ASCII мала слова, бројеви и цртице)",
+	"flag-html":          "`Формат` улаза је (X)HTML",
+	"flag-text":          "`Формат` улаза је прости текст",
+	"flag-c":             "Користи се конфигурација из .translit.yaml, $XDG_CONFIG_HOME/translit/config.yaml или /etc/translit/config.yaml, коју мењају променљиве окружења TRANSLIT_",
+	"flag-profile":       "`Име` профила из конфигурационог фајла чија подешавања се користе, а заставице наведене уз наредбу их мењају",
+	"flag-skip":          "`Обрасци` имена фајлова у директоријуму или zip архиви који се не пресловљавају (нпр. *.css,toc.ncx)",
+	"flag-config":        "Путања до конфигурационог `фајла` који се користи уместо фајла нађеног са -c",
+	"flag-i":             "Путања улазног фајла или директоријума",
+	"flag-columns":       "`Колоне` CSV/TSV фајла које се пресловљавају, по имену из заглавља или редном броју од 1 (нпр. naziv,opis или 2,3)",
+	"flag-header":        "Први ред CSV/TSV фајла је заглавље и не пресловљава се",
+	"flag-attrs":         "`Атрибути` (X)HTML елемената који се пресловљавају (value само на дугмадима)",
+	"flag-meta":          "`Имена` meta елемената чији се content пресловљава",
+	"flag-fragment":      "Улаз је део (X)HTML документа, без html, head и body елемената (препознаје се и сам)",
+	"flag-nolang":        "Не мења се и не додаје lang атрибут html елемента",
+	"flag-stream":        "(X)HTML се пресловљава током читања, уз очување оригиналног означавања",
+	"flag-from-encoding": "`Кодирање` улаза, нпр. windows-1250, windows-1251, iso-8859-2, iso-8859-5, koi8-r, utf-16, yuscii-latin или yuscii-cyrillic (препознаје се само ако се не наведе, осим YUSCII)",
+	"flag-to-encoding":   "`Кодирање` излаза (подразумева се UTF-8)",
+	"flag-diacritics":    "Враћају се дијакритички знаци латиничном тексту куцаном без њих (нпр. zivot у život), и пре пресловљавања у ћирилицу",
+	"flag-lexicon":       "Путања `речника` учестаности речи за враћање дијакритичких знакова (подразумева се уграђени)",
+	"flag-scheme":        "`Шема` пресловљавања: sr (српска азбука и латиница), cnr (црногорска, са С́ и З́), mk (македонска, са Ѓ, Ќ и Ѕ), или само у латиницу iso9 (ISO 9), bgn (BGN/PCGN) и icao (ICAO Doc 9303)",
+	"flag-scheme-file":   "Путања YAML или JSON `фајла` са шемом пресловљавања, која се користи уместо шеме из -scheme",
+	"flag-dump-scheme":   "Исписује шему изабрану са -scheme у `формату` yaml или json, као пример за -scheme-file",
+	"flag-report":        "Путања JSON `фајла` у који се уписује извештај о пресловљавању: број пресловљених речи, страних речи, јединица и заштићених делова по документима",
+	"flag-explain":       "За сваку реч се на стандардни излаз за грешке исписује правило које је одлучило како се пресловљава",
+	"flag-interactive":   "При пресловљавању у ћирилицу се за сваку страну реч и реч са раздвојеним диграфом пита да ли се прихвата одлука правила, пресловљава или не пресловљава",
+	"flag-user-dict":     "Путања `фајла` корисничког речника у који се уписују одлуке из -interactive и из кога се читају у каснијим покретањима",
+	"flag-fail-fast":     "Пресловљавање се прекида на првом фајлу који не може да се преслови, уместо да се настави са осталим",
+	"flag-xliff-source":  "У XLIFF фајлу се пресловљава и <source>, а не само <target>",
+	"flag-addr":          "`Адреса` на којој сервер за пресловљавање слуша",
+	"flag-ui-lang":       "`Језик` порука програма: sr-Cyrl, sr-Latn или en (подразумева се језик из LC_ALL, LC_MESSAGES или LANG)",
+
+	UsageError:    "погрешна употреба заставица",
+	Error:         "Грешка: %v\n",
+	ErrorWithFile: "Грешка у раду са:  %s %v\n",
+
+	ConflictingFlags:         "%w: %s и %s не могу да се наведу заједно",
+	MissingDirection:         "%w: недостаје смер пресловљавања, наведите %s, %s или %s",
+	FlagRequires:             "%w: %s може да се наведе само уз %s",
+	FormatWithInput:          "%w: %s не може да се наведе уз улазни фајл, јер се формат фајла препознаје сам",
+	MissingFormat:            "%w: за стандардни улаз мора да се наведе формат, %s или %s",
+	BadSkipPattern:           "%w: неисправан образац %q у %s",
+	UnknownCommand:           "%w: непозната наредба %s, могуће су: %s",
+	UnexpectedArguments:      "%w: сувишни аргументи: %s",
+	InputGivenTwice:          "%w: улаз је наведен и са %s и као аргумент",
+	MissingAction:            "%w: недостаје радња, могуће су: %s",
+	UnknownAction:            "%w: непозната радња %s, могуће су: %s",
+	MissingArguments:         "%w: радњи %s недостају аргументи",
+	CommandRequires:          "%w: наредби %s је потребна заставица %s",
+	UnknownDecision:          "%w: непозната одлука %q, могуће су accept, convert и protect",
+	WordNotInDictionary:      "реч %s није у корисничком речнику",
+	UnknownDirection:         "непознат смер %s, могући су l2c, c2l и c2a",
+	OnlyPost:                 "текст се шаље само са POST",
+	TextTooLarge:             "текст је дужи од %d бајтова",
+	UnknownSchemeParameter:   "непозната шема %s у параметру scheme, могуће су: %s",
+	LatinOnlySchemeParameter: "шема %s из параметра scheme служи само за пресловљавање у латиницу",
+	Listening:                "Сервер за пресловљавање слуша на http://%s\n",
+	WordsToChange:            "Пресловљавање би променило %d речи\n",
+
+	UnknownUiLanguage:    "непознат језик порука %s, могући су: %s",
+	UnknownScheme:        "%w: непозната шема %s, могуће су: %s",
+	LatinOnlyScheme:      "%w: шема %s служи само за пресловљавање у латиницу",
+	UrlTrailingSlash:     "тренутно није дозвољено да се URL завршава са /",
+	ConfigReadError:      "грешка при читању конфигурационог фајла %s: %w",
+	ConfigError:          "грешка у конфигурационом фајлу %s: %w",
+	ConfigEnvError:       "грешка у променљивим окружења TRANSLIT_: %w",
+	ConfigMissing:        "конфигурациони фајл %s не постоји",
+	ConfigNotFound:       "конфигурациони фајл није нађен, тражен је као %s",
+	UnknownProfile:       "профил %s не постоји у конфигурационом фајлу %s, постоје: %s",
+	ConfigNotMapping:     "%s мора да садржи подешавања са њиховим вредностима",
+	ConfigUnknownKey:     "непознато подешавање %s",
+	ConfigConflict:       "подешавања %s и %s не могу оба да буду true",
+	ConfigNotBool:        "подешавање %s мора да буде true или false",
+	ConfigNotString:      "подешавање %s мора да буде текст",
+	ConfigUnknownVersion: "непозната верзија %s конфигурационог фајла, познате су: %s",
+	ConfigMigrated:       "Упозорење - конфигурациони фајл %s је верзије %s и преводи се у %s, а подешавања у новом облику исписује наредба config show\n",
+	ConfigValid:          "Конфигурациони фајл %s верзије %s је исправан\n",
+
+	Transliterating:     "Пресловљавање\n",
+	Success:             "Успешно: %s \nу %s\n",
+	Archiving:           "Архивирање\nу %s\n",
+	ReportWritten:       "Извештај: %s\n",
+	FailureSummary:      "\nНије пресловљено %d од %d фајлова:\n",
+	SlugTextOnly:        "Упозорење - slug се прави само од простог текста: %s\n",
+	UnsupportedFileType: "Упозорење - тип фајла %s није подржан: %s\n",
+	MissingColumn:       "Упозорење - колона %s не постоји у заглављу: %s\n",
+	AmbiguousWord:       "Упозорење - реч %s може да буде: %s\n",
+	DecisionNotSaved:    "Упозорење - одлука није сачувана у %s: %v\n",
+	ReviewPrompt:        "[п]рихвати, пре[с]лови или [з]аштити (p/s/z)? ",
+	AnswerNotRead:       "\nУпозорење - одговор није прочитан (%v), прихвата се одлука правила\n",
+	NotXliff:            "коренски елемент није xliff",
+	NothingInArchive:    "ниједан фајл у улазној zip архиви није успешно пресловљен",
+	UnterminatedQuote:   "ред %d: недостаје завршни наводник",
+
+	RuleTransliterated:                     "пресловљава се",
+	RuleSerbianWordWithForeignCombinations: "пресловљава се, српска реч са страним комбинацијама слова (%s)",
+	RuleForeignCharacterCombinations:       "не пресловљава се, страна комбинација слова (%s)",
+	RuleCommonForeignWords:                 "не пресловљава се, честа страна реч (%s)",
+	RuleWholeForeignWords:                  "не пресловљава се, цела страна реч (%s)",
+	RuleForeignPrefix:                      "пресловљава се после страног префикса са цртицом (%s)",
+	RuleUnit:                               "не пресловљава се, мерна јединица (%s)",
+	RuleDigraphException:                   "пресловљава се, изузетак за диграф (%s)",
+	SplitDigraph:                           ", пише се %s",
+
+	UnknownEncoding:      "непознато кодирање %q",
+	InputOnlyEncoding:    "кодирање %q може да се користи само за улаз",
+	UnencodableCharacter: "знак %q не постоји у кодирању %q",
+
+	LexiconWordExpected:   "ред %d: очекује се реч или пар речи и учестаност",
+	LexiconBadFrequency:   "ред %d: учестаност %q није позитиван цео број",
+	UserDictWordExpected:  "ред %d: очекује се реч и одлука",
+	UserDictBadDecision:   "ред %d: непозната одлука %q, могуће су accept, convert и protect",
+	InvalidScheme:         "неисправна шема: %w",
+	SchemeWithoutName:     "шема нема име",
+	SchemeExists:          "шема %s већ постоји",
+	SchemeWithoutLanguage: "шема %s нема језике",
+	SchemeWithoutC2l:      "шема %s нема пресловљавање у латиницу (c2l)",
+	DigraphNotInC2l:       "диграф %s није ниједно слово латинице из c2l",
+	DigraphNotInL2c:       "диграф %s који се раздваја није у l2c",
+	DigraphWithoutSplits:  "диграф %s нема речи или замене за раздвајање",
+	InvalidSplit:          "неисправна замена %s у %s за раздвајање диграфа %s",
+	EmptyKey:              "празан кључ у %s",
+	EmptyValue:            "празна вредност за %s у %s",
+	SchemeConflict:        "сукоб у %s: %s се пресловљава и у %s и у %s",
+	UnknownSchemeFormat:   "непознат формат шеме %s, могући су yaml и json",
+}
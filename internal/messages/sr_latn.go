@@ -0,0 +1,144 @@
+// Code generated by go run ./gen; DO NOT EDIT.
+
+package messages
+
+// Messages in Serbian Latin, transliterated from the messages in Serbian Cyrillic
+var latin = map[Key]string{
+	"ambiguous-word":                      "Upozorenje - reč %s može da bude: %s\n",
+	"answer-not-read":                     "\nUpozorenje - odgovor nije pročitan (%v), prihvata se odluka pravila\n",
+	"archiving":                           "Arhiviranje\nu %s\n",
+	"bad-skip-pattern":                    "%w: neispravan obrazac %q u %s",
+	"check-description":                   "Proverava da li je prosti tekst iz fajlova ili sa standardnog ulaza već preslovljen. Ispisuje svaku reč koju bi\npreslovljavanje promenilo, sa fajlom, redom i pravilom, i tada izlazi sa statusom 4.",
+	"check-synopsis":                      "[zastavice] [fajl...]",
+	"command-help-hint":                   "Pomoć o naredbi se dobija sa %s %s --help\n",
+	"command-requires":                    "%w: naredbi %s je potrebna zastavica %s",
+	"command-usage":                       "Upotreba: %s %s %s\n\n%s\n\nZastavice:\n",
+	"config-conflict":                     "podešavanja %s i %s ne mogu oba da budu true",
+	"config-description":                  "Radi sa konfiguracionim fajlom iz --config ili nađenim kao sa -c: validate proverava imena, vrste i kombinacije\npodešavanja i verziju fajla, a show ispisuje podešavanja koja se koriste, sa profilom iz --profile i promenljivama\nokruženja, u obliku najnovije verzije.",
+	"config-env-error":                    "greška u promenljivim okruženja TRANSLIT_: %w",
+	"config-error":                        "greška u konfiguracionom fajlu %s: %w",
+	"config-migrated":                     "Upozorenje - konfiguracioni fajl %s je verzije %s i prevodi se u %s, a podešavanja u novom obliku ispisuje naredba config show\n",
+	"config-missing":                      "konfiguracioni fajl %s ne postoji",
+	"config-not-bool":                     "podešavanje %s mora da bude true ili false",
+	"config-not-found":                    "konfiguracioni fajl nije nađen, tražen je kao %s",
+	"config-not-mapping":                  "%s mora da sadrži podešavanja sa njihovim vrednostima",
+	"config-not-string":                   "podešavanje %s mora da bude tekst",
+	"config-read-error":                   "greška pri čitanju konfiguracionog fajla %s: %w",
+	"config-synopsis":                     "[zastavice] validate | show",
+	"config-unknown-key":                  "nepoznato podešavanje %s",
+	"config-unknown-version":              "nepoznata verzija %s konfiguracionog fajla, poznate su: %s",
+	"config-valid":                        "Konfiguracioni fajl %s verzije %s je ispravan\n",
+	"conflicting-flags":                   "%w: %s i %s ne mogu da se navedu zajedno",
+	"convert-description":                 "Preslovljava standardni ulaz ili ulazni fajl, direktorijum ili zip arhivu, kao i zastavice bez naredbe. Standardni\nulaz je prosti tekst, osim kada se navede --html.",
+	"convert-synopsis":                    "[zastavice] [ulaz]",
+	"decision-not-saved":                  "Upozorenje - odluka nije sačuvana u %s: %v\n",
+	"dict-description":                    "Prikazuje i menja korisnički rečnik iz --user-dict: list ispisuje reči i odluke, set upisuje odluku accept,\nconvert ili protect za reč, a remove briše reč iz rečnika.",
+	"dict-synopsis":                       "[zastavice] list | set reč odluka | remove reč",
+	"digraph-not-in-c2l":                  "digraf %s nije nijedno slovo latinice iz c2l",
+	"digraph-not-in-l2c":                  "digraf %s koji se razdvaja nije u l2c",
+	"digraph-without-splits":              "digraf %s nema reči ili zamene za razdvajanje",
+	"empty-key":                           "prazan ključ u %s",
+	"empty-value":                         "prazna vrednost za %s u %s",
+	"error":                               "Greška: %v\n",
+	"error-with-file":                     "Greška u radu sa:  %s %v\n",
+	"failure-summary":                     "\nNije preslovljeno %d od %d fajlova:\n",
+	"flag-addr":                           "`Adresa` na kojoj server za preslovljavanje sluša",
+	"flag-attrs":                          "`Atributi` (X)HTML elemenata koji se preslovljavaju (value samo na dugmadima)",
+	"flag-c":                              "Koristi se konfiguracija iz .translit.yaml, $XDG_CONFIG_HOME/translit/config.yaml ili /etc/translit/config.yaml, koju menjaju promenljive okruženja TRANSLIT_",
+	"flag-c2a":                            "`Smer` preslovljavanja je ćirilica ili latinica u latinicu bez dijakritičkih znakova (ASCII)",
+	"flag-c2l":                            "`Smer` preslovljavanja je ćirilica u latinicu",
+	"flag-columns":                        "`Kolone` CSV/TSV fajla koje se preslovljavaju, po imenu iz zaglavlja ili rednom broju od 1 (npr. naziv,opis ili 2,3)",
+	"flag-config":                         "Putanja do konfiguracionog `fajla` koji se koristi umesto fajla nađenog sa -c",
+	"flag-diacritics":                     "Vraćaju se dijakritički znaci latiničnom tekstu kucanom bez njih (npr. zivot u život), i pre preslovljavanja u ćirilicu",
+	"flag-dump-scheme":                    "Ispisuje šemu izabranu sa -scheme u `formatu` yaml ili json, kao primer za -scheme-file",
+	"flag-explain":                        "Za svaku reč se na standardni izlaz za greške ispisuje pravilo koje je odlučilo kako se preslovljava",
+	"flag-fail-fast":                      "Preslovljavanje se prekida na prvom fajlu koji ne može da se preslovi, umesto da se nastavi sa ostalim",
+	"flag-fragment":                       "Ulaz je deo (X)HTML dokumenta, bez html, head i body elemenata (prepoznaje se i sam)",
+	"flag-from-encoding":                  "`Kodiranje` ulaza, npr. windows-1250, windows-1251, iso-8859-2, iso-8859-5, koi8-r, utf-16, yuscii-latin ili yuscii-cyrillic (prepoznaje se samo ako se ne navede, osim YUSCII)",
+	"flag-header":                         "Prvi red CSV/TSV fajla je zaglavlje i ne preslovljava se",
+	"flag-html":                           "`Format` ulaza je (X)HTML",
+	"flag-i":                              "Putanja ulaznog fajla ili direktorijuma",
+	"flag-interactive":                    "Pri preslovljavanju u ćirilicu se za svaku stranu reč i reč sa razdvojenim digrafom pita da li se prihvata odluka pravila, preslovljava ili ne preslovljava",
+	"flag-l2c":                            "`Smer` preslovljavanja je latinica u ćirilicu",
+	"flag-lexicon":                        "Putanja `rečnika` učestanosti reči za vraćanje dijakritičkih znakova (podrazumeva se ugrađeni)",
+	"flag-meta":                           "`Imena` meta elemenata čiji se content preslovljava",
+	"flag-nolang":                         "Ne menja se i ne dodaje lang atribut html elementa",
+	"flag-profile":                        "`Ime` profila iz konfiguracionog fajla čija podešavanja se koriste, a zastavice navedene uz naredbu ih menjaju",
+	"flag-report":                         "Putanja JSON `fajla` u koji se upisuje izveštaj o preslovljavanju: broj preslovljenih reči, stranih reči, jedinica i zaštićenih delova po dokumentima",
+	"flag-requires":                       "%w: %s može da se navede samo uz %s",
+	"flag-scheme":                         "`Šema` preslovljavanja: sr (srpska azbuka i latinica), cnr (crnogorska, sa Ś i Ź), mk (makedonska, sa Ѓ, Ќ i Ѕ), ili samo u latinicu iso9 (ISO 9), bgn (BGN/PCGN) i icao (ICAO Doc 9303)",
+	"flag-scheme-file":                    "Putanja YAML ili JSON `fajla` sa šemom preslovljavanja, koja se koristi umesto šeme iz -scheme",
+	"flag-skip":                           "`Obrasci` imena fajlova u direktorijumu ili zip arhivi koji se ne preslovljavaju (npr. *.css,toc.ncx)",
+	"flag-slug":                           "Svaki red prostog teksta se pretvara u slug za veb adrese i imena fajlova (ASCII mala slova, brojevi i crtice)",
+	"flag-stream":                         "(X)HTML se preslovljava tokom čitanja, uz očuvanje originalnog označavanja",
+	"flag-text":                           "`Format` ulaza je prosti tekst",
+	"flag-to-encoding":                    "`Kodiranje` izlaza (podrazumeva se UTF-8)",
+	"flag-ui-lang":                        "`Jezik` poruka programa: sr-Cyrl, sr-Latn ili en (podrazumeva se jezik iz LC_ALL, LC_MESSAGES ili LANG)",
+	"flag-user-dict":                      "Putanja `fajla` korisničkog rečnika u koji se upisuju odluke iz -interactive i iz koga se čitaju u kasnijim pokretanjima",
+	"flag-xliff-source":                   "U XLIFF fajlu se preslovljava i <source>, a ne samo <target>",
+	"format-with-input":                   "%w: %s ne može da se navede uz ulazni fajl, jer se format fajla prepoznaje sam",
+	"help-commands":                       "\nNaredbe, sa dugim zastavicama (--l2c, --input), umesto zastavica bez naredbe:\n  convert\tpreslovljava tekst i fajlove\n  check\t\tproverava da li je tekst već preslovljen\n  serve\t\tpokreće HTTP server za preslovljavanje\n  dict\t\tprikazuje i menja korisnički rečnik\n  config\tproverava i prikazuje konfiguraciju\nPomoć o naredbi se dobija sa %[1]s <naredba> --help\n",
+	"help-details":                        "\nKada se navede -c, -config ili -profile, program se podešava čitanjem konfiguracije, a ostale\nnavedene zastavice menjaju njena podešavanja. U suprotnom, mora da se navede po jedna i samo jedna zastavica iz obe grupe\nSmer i Format. Kada se navede zastavica za ulazni fajl potrebno je da se navede samo zastavica smera.\nCele reči između „<|” i „|>” u prostom tekstu se ne preslovljavaju.\nTekst unutar <span lang=\"sr-Latn\"></span> elementa u (X)HTML se ne preslovljava u ćirilicu,\na tekst unutar <span lang=\"sr-Cyrl\"></span> se ne preslovljava u latinicu.\n\nPrimeri:\n%[1]s -l2c -html\t\tpreslovi (X)HTML u ćirilicu\n%[1]s -text -c2l\t\tpreslovi prosti tekst u latinicu\n%[1]s -text -slug\t\tnapravi slug od svakog reda prostog teksta\n%[1]s -c\t\t\tprogram čita podešavanja iz fajla konfiguracije\n",
+	"help-header":                         "Ovo je filter %s verzija %s\nSastavio eevan78, 2024-%v\n\n",
+	"help-hint":                           "Pomoć se dobija sa %s -h\n",
+	"help-intro":                          "Filter čita UTF-8 kodirani tekst sa standardnog ulaza ili iz navedenog fajla i ispisuje ga na\nstandardni izlaz ili u izlazni fajl, preslovljen saglasno sa sledećim zastavicama:\n",
+	"input-given-twice":                   "%w: ulaz je naveden i sa %s i kao argument",
+	"input-only-encoding":                 "kodiranje %q može da se koristi samo za ulaz",
+	"invalid-scheme":                      "neispravna šema: %w",
+	"invalid-split":                       "neispravna zamena %s u %s za razdvajanje digrafa %s",
+	"latin-only-scheme":                   "%w: šema %s služi samo za preslovljavanje u latinicu",
+	"latin-only-scheme-parameter":         "šema %s iz parametra scheme služi samo za preslovljavanje u latinicu",
+	"lexicon-bad-frequency":               "red %d: učestanost %q nije pozitivan ceo broj",
+	"lexicon-word-expected":               "red %d: očekuje se reč ili par reči i učestanost",
+	"listening":                           "Server za preslovljavanje sluša na http://%s\n",
+	"missing-action":                      "%w: nedostaje radnja, moguće su: %s",
+	"missing-arguments":                   "%w: radnji %s nedostaju argumenti",
+	"missing-column":                      "Upozorenje - kolona %s ne postoji u zaglavlju: %s\n",
+	"missing-direction":                   "%w: nedostaje smer preslovljavanja, navedite %s, %s ili %s",
+	"missing-format":                      "%w: za standardni ulaz mora da se navede format, %s ili %s",
+	"not-xliff":                           "korenski element nije xliff",
+	"nothing-in-archive":                  "nijedan fajl u ulaznoj zip arhivi nije uspešno preslovljen",
+	"only-post":                           "tekst se šalje samo sa POST",
+	"report-written":                      "Izveštaj: %s\n",
+	"review-prompt":                       "[p]rihvati, pre[s]lovi ili [z]aštiti (p/s/z)? ",
+	"rule-common-foreign-words":           "ne preslovljava se, česta strana reč (%s)",
+	"rule-digraph-exception":              "preslovljava se, izuzetak za digraf (%s)",
+	"rule-foreign-character-combinations": "ne preslovljava se, strana kombinacija slova (%s)",
+	"rule-foreign-prefix":                 "preslovljava se posle stranog prefiksa sa crticom (%s)",
+	"rule-serbian-word-with-foreign-combinations": "preslovljava se, srpska reč sa stranim kombinacijama slova (%s)",
+	"rule-transliterated":                         "preslovljava se",
+	"rule-unit":                                   "ne preslovljava se, merna jedinica (%s)",
+	"rule-whole-foreign-words":                    "ne preslovljava se, cela strana reč (%s)",
+	"scheme-conflict":                             "sukob u %s: %s se preslovljava i u %s i u %s",
+	"scheme-exists":                               "šema %s već postoji",
+	"scheme-without-c2l":                          "šema %s nema preslovljavanje u latinicu (c2l)",
+	"scheme-without-language":                     "šema %s nema jezike",
+	"scheme-without-name":                         "šema nema ime",
+	"serve-description":                           "Pokreće HTTP server koji preslovljava tekst poslat sa POST na /l2c, /c2l ili /c2a i vraća ga kao prosti tekst.\nŠema se bira parametrom scheme, a podrazumeva se šema iz --scheme.",
+	"serve-synopsis":                              "[zastavice]",
+	"slug-text-only":                              "Upozorenje - slug se pravi samo od prostog teksta: %s\n",
+	"split-digraph":                               ", piše se %s",
+	"success":                                     "Uspešno: %s \nu %s\n",
+	"text-too-large":                              "tekst je duži od %d bajtova",
+	"transliterating":                             "Preslovljavanje\n",
+	"unencodable-character":                       "znak %q ne postoji u kodiranju %q",
+	"unexpected-arguments":                        "%w: suvišni argumenti: %s",
+	"unknown-action":                              "%w: nepoznata radnja %s, moguće su: %s",
+	"unknown-command":                             "%w: nepoznata naredba %s, moguće su: %s",
+	"unknown-decision":                            "%w: nepoznata odluka %q, moguće su accept, convert i protect",
+	"unknown-direction":                           "nepoznat smer %s, mogući su l2c, c2l i c2a",
+	"unknown-encoding":                            "nepoznato kodiranje %q",
+	"unknown-profile":                             "profil %s ne postoji u konfiguracionom fajlu %s, postoje: %s",
+	"unknown-scheme":                              "%w: nepoznata šema %s, moguće su: %s",
+	"unknown-scheme-format":                       "nepoznat format šeme %s, mogući su yaml i json",
+	"unknown-scheme-parameter":                    "nepoznata šema %s u parametru scheme, moguće su: %s",
+	"unknown-ui-language":                         "nepoznat jezik poruka %s, mogući su: %s",
+	"unsupported-file-type":                       "Upozorenje - tip fajla %s nije podržan: %s\n",
+	"unterminated-quote":                          "red %d: nedostaje završni navodnik",
+	"url-trailing-slash":                          "trenutno nije dozvoljeno da se URL završava sa /",
+	"usage-error":                                 "pogrešna upotreba zastavica",
+	"user-dict-bad-decision":                      "red %d: nepoznata odluka %q, moguće su accept, convert i protect",
+	"user-dict-word-expected":                     "red %d: očekuje se reč i odluka",
+	"word-not-in-dictionary":                      "reč %s nije u korisničkom rečniku",
+	"words-to-change":                             "Preslovljavanje bi promenilo %d reči\n",
+}
@@ -2,7 +2,8 @@ package terminal
 
 import (
 	"bufio"
-	"fmt"
+	"errors"
+	"io"
 	"os"
 )
 
@@ -11,26 +12,40 @@ type FileOperator struct {
 	OutputFile *os.File
 	Reader     *bufio.Reader
 	Writer     *bufio.Writer
+	Encoder    io.Closer
+	Markup     bool // HTML or XML output, where the characters missing from the output encoding are escaped
 }
 
-func (fop *FileOperator) Open(filePath string) {
-	fop.InputFile, fop.Reader = OpenInputFile(filePath)
+func (fop *FileOperator) Open(filePath string) (err error) {
+	fop.InputFile, fop.Reader, err = OpenInputFile(filePath)
+	return err
 }
 
-func (fop *FileOperator) Create(filePath string) {
-	fop.OutputFile, fop.Writer = CreateOutputFile(filePath)
+func (fop *FileOperator) Create(filePath string) (err error) {
+	fop.OutputFile, fop.Writer, fop.Encoder, err = CreateOutputFile(filePath, fop.Markup)
+	return err
 }
 
-func (fop *FileOperator) Close() {
-	err := fop.InputFile.Close()
-	if err != nil {
-		// could be ommited beacuse it is safe for us to call close twice, but printing for developers
-		fmt.Println("Фајл је већ затворен:", fop.InputFile)
+// Opens the input file and creates the output file.
+func (fop *FileOperator) OpenAndCreate(inputFilePath string, outputFilePath string) error {
+	if err := fop.Open(inputFilePath); err != nil {
+		return err
 	}
+	return fop.Create(outputFilePath)
+}
+
+// Closes the input file, and the output file after the encoder writes out what it still holds.
+func (fop *FileOperator) Close() error {
+	return errors.Join(fop.InputFile.Close(), fop.Encoder.Close(), fop.OutputFile.Close())
+}
 
-	err = fop.OutputFile.Close()
-	if err != nil {
-		// could be ommited beacuse it is safe for us to call close twice, but printing for developers
-		fmt.Println("Фајл је већ затворен:", fop.OutputFile)
+// Closes the files after a failure and removes the incomplete output file.
+func (fop *FileOperator) Abort() {
+	if fop.InputFile != nil {
+		fop.InputFile.Close()
+	}
+	if fop.OutputFile != nil {
+		fop.OutputFile.Close()
+		os.Remove(fop.OutputFile.Name())
 	}
 }
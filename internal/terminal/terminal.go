@@ -5,14 +5,16 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/cavaliergopher/grab/v3"
+	"github.com/eevan78/translit/internal/charset"
 	"github.com/eevan78/translit/internal/dictionary"
 	"github.com/eevan78/translit/internal/exit"
-	"github.com/gabriel-vasile/mimetype"
+	"github.com/eevan78/translit/internal/messages"
 )
 
 var (
@@ -25,52 +27,106 @@ var (
 	TmpDir          = "tmp"
 )
 
-func OpenInputFile(filename string) (*os.File, *bufio.Reader) {
+func OpenInputFile(filename string) (*os.File, *bufio.Reader, error) {
 	inputFile, err := os.Open(filename)
 	if err != nil {
-		exit.ExitWithError(err, filename)
+		return nil, nil, err
 	}
 
-	rdr = bufio.NewReader(inputFile)
-	return inputFile, rdr
+	rdr, err = DecodeReader(inputFile)
+	if err != nil {
+		inputFile.Close()
+		return nil, nil, err
+	}
+	return inputFile, rdr, nil
 }
 
-func CreateOutputFile(filename string) (*os.File, *bufio.Writer) {
+// Creates the output file, and returns the writer of the output and the encoder, which is closed after the writer
+// is flushed. Characters missing from the output encoding are escaped only in markup.
+func CreateOutputFile(filename string, markup bool) (*os.File, *bufio.Writer, io.Closer, error) {
 	outputFile, err := os.Create(filename)
 	if err != nil {
-		exit.ExitWithError(err, filename)
+		return nil, nil, nil, err
 	}
 
-	out = bufio.NewWriter(outputFile)
-	return outputFile, out
+	out, encoder, err := EncodeWriter(outputFile, markup)
+	if err != nil {
+		outputFile.Close()
+		return nil, nil, nil, err
+	}
+	return outputFile, out, encoder, nil
 }
 
-func prepareInputDirectory() {
+// Returns a reader of UTF-8 decoded from the input in the encoding given with the flag, or in the detected one.
+// UTF-8 input is read as it is. The encoding is detected from what a single read returns, so that a filter on
+// the standard input does not wait for the whole sample before it writes the first line.
+func DecodeReader(input io.Reader) (*bufio.Reader, error) {
+	reader := bufio.NewReaderSize(input, charset.SampleSize)
+	encodingName := *dictionary.FromEncodingPtr
+	if encodingName == "" {
+		_, err := reader.Peek(1)
+		sample, _ := reader.Peek(reader.Buffered())
+		encodingName = charset.Detect(sample, err == nil)
+	}
+	if charset.IsUTF8(encodingName) && *dictionary.FromEncodingPtr == "" {
+		return reader, nil
+	}
+
+	decoded, err := charset.NewReader(reader, encodingName)
+	if err != nil {
+		return nil, err
+	}
+	return bufio.NewReader(decoded), nil
+}
+
+// Returns a writer which encodes the UTF-8 output to the encoding given with the flag, and the encoder, which has
+// to be closed after the writer is flushed, so that a stateful encoding is ended properly. Characters which do not
+// exist in the encoding are written as character references in HTML and XML, and are an error in other formats.
+func EncodeWriter(output io.Writer, markup bool) (*bufio.Writer, io.Closer, error) {
+	encoded, err := charset.NewWriter(output, OutputEncoding(), markup)
+	if err != nil {
+		return nil, nil, err
+	}
+	return bufio.NewWriter(encoded), encoded, nil
+}
+
+// Returns the encoding of the output, which is UTF-8 unless it is given with the flag.
+func OutputEncoding() string {
+	if *dictionary.ToEncodingPtr == "" {
+		return charset.UTF8
+	}
+	return *dictionary.ToEncodingPtr
+}
+
+func prepareInputDirectory() error {
 	inputDir, err := os.Open(*dictionary.InputPathPtr)
 	if err != nil {
-		exit.ExitWithError(err, *dictionary.InputPathPtr)
+		return err
 	}
+	defer inputDir.Close()
 
 	InputFilenames, err = inputDir.Readdirnames(0)
 	if err != nil {
-		exit.ExitWithError(err, *dictionary.InputPathPtr)
+		return err
 	}
 
 	absPath, _ := filepath.Abs(*dictionary.InputPathPtr)
 	for i := range InputFilenames {
 		InputFilePaths = append(InputFilePaths, filepath.Join(absPath, InputFilenames[i]))
 	}
+	return nil
 }
 
-func PrepareInputDirectoryForZip(directoryPath string) (filePaths []string) {
+func PrepareInputDirectoryForZip(directoryPath string) (filePaths []string, err error) {
 	inputDir, err := os.Open(directoryPath)
 	if err != nil {
-		exit.ExitWithError(err, directoryPath)
+		return nil, err
 	}
+	defer inputDir.Close()
 
 	fileNames, err := inputDir.Readdirnames(0)
 	if err != nil {
-		exit.ExitWithError(err, directoryPath)
+		return nil, err
 	}
 
 	absPath, _ := filepath.Abs(directoryPath)
@@ -78,15 +134,15 @@ func PrepareInputDirectoryForZip(directoryPath string) (filePaths []string) {
 		filePaths = append(filePaths, filepath.Join(absPath, fileNames[i]))
 	}
 
-	return filePaths
+	return filePaths, nil
 }
 
-func prepareOutputDirectory() {
+func prepareOutputDirectory() error {
 	outDirName := filepath.Join(filepath.Dir(*dictionary.InputPathPtr), OutputDir)
 	if _, err := os.Stat(outDirName); errors.Is(err, os.ErrNotExist) {
 		err := os.MkdirAll(outDirName, os.ModePerm)
 		if err != nil {
-			exit.ExitWithError(err, outDirName)
+			return err
 		}
 	}
 
@@ -94,13 +150,14 @@ func prepareOutputDirectory() {
 	for i := range InputFilenames {
 		OutputFilePaths = append(OutputFilePaths, filepath.Join(absPath, InputFilenames[i]))
 	}
+	return nil
 }
 
-func PrepareOutputDirectoryForZip(inputDirectoryPath string, inputFilePaths []string, outputDirectoryPath string) (outputFilePaths []string) {
+func PrepareOutputDirectoryForZip(inputDirectoryPath string, inputFilePaths []string, outputDirectoryPath string) (outputFilePaths []string, err error) {
 	if _, err := os.Stat(outputDirectoryPath); errors.Is(err, os.ErrNotExist) {
 		err := os.MkdirAll(outputDirectoryPath, os.ModePerm)
 		if err != nil {
-			exit.ExitWithError(err, outputDirectoryPath)
+			return nil, err
 		}
 	}
 
@@ -111,43 +168,45 @@ func PrepareOutputDirectoryForZip(inputDirectoryPath string, inputFilePaths []st
 		outputFilePaths = append(outputFilePaths, filepath.Join(absPath, inputFileNames[i]))
 	}
 
-	return outputFilePaths
+	return outputFilePaths, nil
 }
 
-func PrepareZipDirectories(inputFilePath string) (tempDir string, outputDir string) {
+func PrepareZipDirectories(inputFilePath string) (tempDir string, outputDir string, err error) {
 	// directory to place all archived files has the same name as the archive
 	dirName := strings.Split(filepath.Base(inputFilePath), ".")[0]
 
 	// Create a temporary directory with a custom prefix
-	tempDir, err := os.MkdirTemp("", dirName)
+	tempDir, err = os.MkdirTemp("", dirName)
 	if err != nil {
-		exit.ExitWithError(err, "Error creating temporary directory")
+		return "", "", err
 	}
 
 	outputDir, err = os.MkdirTemp("", "output")
 	if err != nil {
-		exit.ExitWithError(err, "Error creating temporary directory")
+		os.RemoveAll(tempDir)
+		return "", "", err
 	}
 
-	return tempDir, outputDir
+	return tempDir, outputDir, nil
 }
 
-func prepareInputFile() {
+func prepareInputFile() error {
 	if strings.HasPrefix(*dictionary.InputPathPtr, "http") {
-		prepareInputFileFromInternet()
+		if err := prepareInputFileFromInternet(); err != nil {
+			return err
+		}
 	}
 
 	// strip directories from the input filepath if exist
 	InputFilenames = append(InputFilenames, filepath.Base(*dictionary.InputPathPtr))
 	absPath, _ := filepath.Abs(*dictionary.InputPathPtr)
 	InputFilePaths = append(InputFilePaths, absPath)
+	return nil
 }
 
-func prepareInputFileFromInternet() {
-	var err error
+func prepareInputFileFromInternet() error {
 	if strings.HasSuffix(*dictionary.InputPathPtr, "/") {
-		err = errors.New("тренутно није дозвољено да се URL завршава са /")
-		exit.ExitWithError(err, *dictionary.InputPathPtr)
+		return messages.Errorf(messages.UrlTrailingSlash)
 	}
 
 	tmpDir := "tmp"
@@ -155,17 +214,17 @@ func prepareInputFileFromInternet() {
 	if _, err := os.Stat(tmpDir); errors.Is(err, os.ErrNotExist) {
 		err := os.Mkdir(tmpDir, os.ModePerm)
 		if err != nil {
-			exit.ExitWithError(err, tmpDir)
+			return err
 		}
 	}
 
-	var response *grab.Response
 	//download file to the tmp directory
-	response, err = grab.Get(tmpDir, *dictionary.InputPathPtr)
+	response, err := grab.Get(tmpDir, *dictionary.InputPathPtr)
 	if err != nil {
-		exit.ExitWithError(err, *dictionary.InputPathPtr)
+		return err
 	}
 	*dictionary.InputPathPtr = response.Filename
+	return nil
 }
 
 func isDirectory(path string) (bool, error) {
@@ -186,60 +245,167 @@ func isDirectory(path string) (bool, error) {
 func ProcessFlags() {
 	flag.Usage = exit.Pomoc
 	flag.Parse()
+	// the language may also be given in the configuration, and then it is checked with the rest of the flags
+	_ = exit.Localize()
 }
 
-func CheckFlags() {
+// Returns the values of the flags given in the command line, which override the configuration.
+func GivenFlags() map[string]flag.Value {
+	given := map[string]flag.Value{}
+	flag.Visit(func(f *flag.Flag) {
+		given[f.Name] = f.Value
+	})
+	return given
+}
+
+// Checks the flags of the program called without a command and loads the scheme they select. Returns an error
+// wrapping exit.ErrUsage, which names the flags, when the flags are used the wrong way.
+func CheckFlags() error {
+	return checkFlags(func(name string) string { return "-" + name }, true)
+}
+
+// Checks the flags of the convert command, which are named by the function in the messages, and loads the scheme
+// they select. Unlike without a command, the format of the standard input is plain text unless it is given.
+func CheckConvertFlags(flagName func(string) string) error {
+	return checkFlags(flagName, false)
+}
+
+func checkFlags(flagName func(string) string, legacy bool) error {
+	if err := exit.Localize(); err != nil {
+		return fmt.Errorf("%w: %v", exit.ErrUsage, err)
+	}
+
+	// input in a given encoding, like YUSCII, can be only decoded, and Latin text can only get its diacritics
+	// restored, without transliteration, so no direction is needed
+	withoutDirection := *dictionary.FromEncodingPtr != "" || *dictionary.DiacriticsPtr
+
+	// slug is made only of plain text and always in ASCII
+	if *dictionary.SlugPtr {
+		if conflicting := setFlags(setFlag{"l2c", *dictionary.L2cPtr}, setFlag{"c2l", *dictionary.C2lPtr},
+			setFlag{"html", *dictionary.HtmlPtr}, setFlag{"diacritics", *dictionary.DiacriticsPtr}); len(conflicting) > 0 {
+			return messages.Errorf(messages.ConflictingFlags, exit.ErrUsage, flagName("slug"), flagName(conflicting[0]))
+		}
+		*dictionary.C2aPtr = true
+	}
+
+	if err := CheckScheme(); err != nil {
+		return err
+	}
+
+	// the scheme is only written out, so nothing else is needed
+	if *dictionary.DumpSchemePtr != "" {
+		return nil
+	}
+
+	// the direction of the slug is not given with the flags
+	directions := setFlags(setFlag{"l2c", *dictionary.L2cPtr}, setFlag{"c2l", *dictionary.C2lPtr},
+		setFlag{"c2a", *dictionary.C2aPtr && !*dictionary.SlugPtr})
+	if len(directions) > 1 {
+		return messages.Errorf(messages.ConflictingFlags, exit.ErrUsage, flagName(directions[0]), flagName(directions[1]))
+	}
+	if len(directions) == 0 && !withoutDirection && !*dictionary.SlugPtr {
+		return messages.Errorf(messages.MissingDirection, exit.ErrUsage, flagName("l2c"), flagName("c2l"), flagName("c2a"))
+	}
+
+	// the words on the edge of the foreign word rules are reviewed only when transliterating to the Cyrillic script
+	if *dictionary.InteractivePtr && !*dictionary.L2cPtr {
+		return messages.Errorf(messages.FlagRequires, exit.ErrUsage, flagName("interactive"), flagName("l2c"))
+	}
+
+	// diacritics are restored only in Latin text which is not converted to ASCII
+	if *dictionary.DiacriticsPtr && len(directions) == 1 && directions[0] != "l2c" {
+		return messages.Errorf(messages.ConflictingFlags, exit.ErrUsage, flagName("diacritics"), flagName(directions[0]))
+	}
+
+	for _, pattern := range strings.Split(*dictionary.SkipPtr, ",") {
+		if _, err := filepath.Match(strings.TrimSpace(pattern), ""); err != nil {
+			return messages.Errorf(messages.BadSkipPattern, exit.ErrUsage, pattern, flagName("skip"))
+		}
+	}
+
+	if *dictionary.HtmlPtr && *dictionary.TextPtr {
+		return messages.Errorf(messages.ConflictingFlags, exit.ErrUsage, flagName("html"), flagName("text"))
+	}
 	if *dictionary.InputPathPtr != "" {
-		// file no matter config
-		if *dictionary.L2cPtr == *dictionary.C2lPtr || *dictionary.HtmlPtr || *dictionary.TextPtr {
-			exit.ExitWithHelp()
+		// the format of a file is detected, whether the flags are given or read from the configuration
+		if formats := setFlags(setFlag{"html", *dictionary.HtmlPtr}, setFlag{"text", *dictionary.TextPtr}); len(formats) > 0 {
+			return messages.Errorf(messages.FormatWithInput, exit.ErrUsage, flagName(formats[0]))
 		}
-	} else {
-		// std in
-		arguments := os.Args[1:]
-		if *dictionary.ConfigPtr {
-			// config
-			if len(arguments) == 1 {
-				// program called only with -c flag so we test config
-				if *dictionary.L2cPtr == *dictionary.C2lPtr || *dictionary.HtmlPtr == *dictionary.TextPtr {
-					exit.ExitWithHelp()
-				}
-			} else {
-				// program called with multiple flags
-				exit.ExitWithHelp()
-			}
-		} else {
-			// no config
-			if *dictionary.L2cPtr == *dictionary.C2lPtr || *dictionary.HtmlPtr == *dictionary.TextPtr {
-				exit.ExitWithHelp()
-			}
+		return nil
+	}
+
+	if !legacy {
+		if !*dictionary.HtmlPtr {
+			*dictionary.TextPtr = true
+		}
+		return nil
+	}
+	if !*dictionary.HtmlPtr && !*dictionary.TextPtr {
+		return messages.Errorf(messages.MissingFormat, exit.ErrUsage, flagName("html"), flagName("text"))
+	}
+	return nil
+}
+
+type setFlag struct {
+	name string
+	set  bool
+}
+
+// Returns the names of the flags which are set, in the given order.
+func setFlags(flags ...setFlag) []string {
+	var names []string
+	for _, value := range flags {
+		if value.set {
+			names = append(names, value.name)
 		}
 	}
+	return names
+}
+
+// Loads the scheme from the file given with the flag, if there is one, and checks that the selected scheme exists
+// and can be used in the selected direction.
+func CheckScheme() error {
+	if *dictionary.SchemeFilePtr != "" {
+		name, err := dictionary.LoadScheme(*dictionary.SchemeFilePtr)
+		if err != nil {
+			return fmt.Errorf("%s: %w", *dictionary.SchemeFilePtr, err)
+		}
+		*dictionary.SchemePtr = name
+	}
+	if scheme, ok := dictionary.Schemes[*dictionary.SchemePtr]; !ok {
+		return messages.Errorf(messages.UnknownScheme, exit.ErrUsage, *dictionary.SchemePtr, strings.Join(dictionary.SchemeNames(), ", "))
+	} else if scheme.L2c == nil && *dictionary.L2cPtr {
+		return messages.Errorf(messages.LatinOnlyScheme, exit.ErrUsage, *dictionary.SchemePtr)
+	}
+	return nil
+}
+
+// Writes the scheme selected by the flags to the standard output, if requested. Returns whether it was written.
+func DumpScheme() (bool, error) {
+	if *dictionary.DumpSchemePtr == "" {
+		return false, nil
+	}
+	return true, dictionary.DumpScheme(os.Stdout, dictionary.CurrentScheme(), *dictionary.DumpSchemePtr)
 }
 
-func ProcessFilePaths() {
+// Prepares the paths of the input files and of the output files for them, when the input is not the standard input.
+func ProcessFilePaths() error {
 	if *dictionary.InputPathPtr != "" {
 		isDirectory, err := isDirectory(*dictionary.InputPathPtr)
 		if err != nil {
-			exit.ExitWithError(err, *dictionary.InputPathPtr)
+			return err
 		}
 
 		if isDirectory {
-			prepareInputDirectory()
+			err = prepareInputDirectory()
 		} else {
-			prepareInputFile()
+			err = prepareInputFile()
+		}
+		if err != nil {
+			return err
 		}
 
-		prepareOutputDirectory()
-	}
-}
-
-func DetectFileType(filePath string) (string, string) {
-	mediaType, err := mimetype.DetectFile(filePath)
-	if err != nil {
-		panic(err)
+		return prepareOutputDirectory()
 	}
-	fmt.Println(mediaType.String(), mediaType.Extension())
-
-	return mediaType.String(), mediaType.Extension()
+	return nil
 }
@@ -0,0 +1,117 @@
+// Package userdict keeps the decisions of the user about the words on the edge of the rules for foreign words
+// and digraphs, so that the words are transliterated the same way in every later run.
+package userdict
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/eevan78/translit/internal/messages"
+)
+
+// Decision about how a word is transliterated to the Cyrillic script
+type Decision string
+
+const (
+	Accept  Decision = "accept"  // transliterated as the rules decide
+	Convert Decision = "convert" // transliterated letter by letter, as a Serbian word without split digraphs
+	Protect Decision = "protect" // left intact, like a foreign word
+)
+
+const header = "# Кориснички речник: реч и одлука accept (како одлуче правила), convert (пресловљава се) или protect (не пресловљава се)\n"
+
+// Dictionary holds the decisions by the word in lower case.
+type Dictionary struct {
+	decisions map[string]Decision
+}
+
+func New() *Dictionary {
+	return &Dictionary{decisions: map[string]Decision{}}
+}
+
+// Loads the dictionary from the file. A file which does not exist yet is an empty dictionary.
+func Load(filePath string) (*Dictionary, error) {
+	file, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Parse(file)
+}
+
+// Parses the dictionary, which has a word and the decision about it per line. Empty lines and lines starting
+// with # are skipped.
+func Parse(reader io.Reader) (*Dictionary, error) {
+	dictionary := New()
+	scanner := bufio.NewScanner(reader)
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		fields := strings.Fields(entry)
+		if len(fields) != 2 {
+			return nil, messages.Errorf(messages.UserDictWordExpected, line)
+		}
+		decision, ok := ParseDecision(fields[1])
+		if !ok {
+			return nil, messages.Errorf(messages.UserDictBadDecision, line, fields[1])
+		}
+		dictionary.decisions[strings.ToLower(fields[0])] = decision
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return dictionary, nil
+}
+
+// Returns the decision with the name, if there is one.
+func ParseDecision(name string) (Decision, bool) {
+	decision := Decision(name)
+	return decision, decision == Accept || decision == Convert || decision == Protect
+}
+
+// Returns the decision about the word, regardless of its case.
+func (dictionary *Dictionary) Lookup(word string) (Decision, bool) {
+	decision, ok := dictionary.decisions[strings.ToLower(word)]
+	return decision, ok
+}
+
+func (dictionary *Dictionary) Set(word string, decision Decision) {
+	dictionary.decisions[strings.ToLower(word)] = decision
+}
+
+// Removes the decision about the word. Returns whether there was one.
+func (dictionary *Dictionary) Remove(word string) bool {
+	word = strings.ToLower(word)
+	_, ok := dictionary.decisions[word]
+	delete(dictionary.decisions, word)
+	return ok
+}
+
+// Returns the words of the dictionary, sorted.
+func (dictionary *Dictionary) Words() []string {
+	words := make([]string, 0, len(dictionary.decisions))
+	for word := range dictionary.decisions {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+	return words
+}
+
+// Writes the dictionary to the file, with the words sorted.
+func (dictionary *Dictionary) Save(filePath string) error {
+	var sb strings.Builder
+	sb.WriteString(header)
+	for _, word := range dictionary.Words() {
+		fmt.Fprintf(&sb, "%s %s\n", word, dictionary.decisions[word])
+	}
+	return os.WriteFile(filePath, []byte(sb.String()), 0644)
+}
@@ -0,0 +1,99 @@
+// Package translit transliterates Serbian text between the Latin and the Cyrillic script, converts it to plain
+// ASCII and makes slugs for web addresses and file names, for use in other Go programs. The functions handle
+// foreign words, digraphs and punctuation the same way the translit filter does, but do not depend on its flags.
+//
+// The functions without a scheme use the Serbian alphabets. The others take the name of a scheme, like cnr for
+// Montenegrin, mk for Macedonian, or iso9, bgn and icao for the standardized romanizations of the Cyrillic script.
+package translit
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/eevan78/translit/internal/dictionary"
+	"github.com/eevan78/translit/internal/language"
+)
+
+var (
+	// The scheme with the given name does not exist.
+	ErrUnknownScheme = errors.New("unknown scheme")
+	// The scheme is a romanization, which transliterates only to the Latin script.
+	ErrLatinOnlyScheme = errors.New("scheme transliterates only to the Latin script")
+)
+
+// Transliterates Serbian Latin text to the Cyrillic script, leaving foreign words intact.
+func LatinToCyrillic(text string) string {
+	return language.TransliterateText(text, language.LatinToCyrillic, dictionary.Schemes["sr"])
+}
+
+// Transliterates Serbian Cyrillic text to the Latin script.
+func CyrillicToLatin(text string) string {
+	return language.TransliterateText(text, language.CyrillicToLatin, dictionary.Schemes["sr"])
+}
+
+// Converts Serbian text in either script to the Latin script without diacritics, e.g. Ђорђе Чолић to Djordje Colic.
+func ToASCII(text string) string {
+	return language.TransliterateText(text, language.ToASCII, dictionary.Schemes["sr"])
+}
+
+// Makes a slug of Serbian text in either script, e.g. Ђорђе Чолић! to djordje-colic.
+func Slug(text string) string {
+	return language.Slug(text, dictionary.Schemes["sr"])
+}
+
+// Transliterates Latin text to the Cyrillic script with the named scheme. The romanization schemes can not
+// be used in this direction, and return an error wrapping ErrLatinOnlyScheme.
+func LatinToCyrillicWithScheme(text string, scheme string) (string, error) {
+	selected, err := lookupScheme(scheme)
+	if err != nil {
+		return "", err
+	}
+	if selected.L2c == nil {
+		return "", fmt.Errorf("%w: %s", ErrLatinOnlyScheme, scheme)
+	}
+	return language.TransliterateText(text, language.LatinToCyrillic, selected), nil
+}
+
+// Transliterates Cyrillic text to the Latin script with the named scheme, e.g. Љубљана to L̂ubl̂ana with iso9.
+func CyrillicToLatinWithScheme(text string, scheme string) (string, error) {
+	selected, err := lookupScheme(scheme)
+	if err != nil {
+		return "", err
+	}
+	return language.TransliterateText(text, language.CyrillicToLatin, selected), nil
+}
+
+// Explanation tells how a word is transliterated and which rule decided it, e.g. that a word is left intact
+// because it starts like a common foreign word, or that its digraph is split into two letters.
+type Explanation = language.Explanation
+
+// Explains, word by word, how Serbian Latin text is transliterated to the Cyrillic script.
+func Explain(text string) []Explanation {
+	return language.ExplainText(text, language.LatinToCyrillic, dictionary.Schemes["sr"])
+}
+
+// Explains, word by word, how Latin text is transliterated to the Cyrillic script with the named scheme.
+func ExplainWithScheme(text string, scheme string) ([]Explanation, error) {
+	selected, err := lookupScheme(scheme)
+	if err != nil {
+		return nil, err
+	}
+	if selected.L2c == nil {
+		return nil, fmt.Errorf("%w: %s", ErrLatinOnlyScheme, scheme)
+	}
+	return language.ExplainText(text, language.LatinToCyrillic, selected), nil
+}
+
+// Returns the names of the schemes.
+func Schemes() []string {
+	return dictionary.SchemeNames()
+}
+
+// Returns the named scheme, or an error wrapping ErrUnknownScheme.
+func lookupScheme(name string) (*dictionary.Scheme, error) {
+	scheme, ok := dictionary.Schemes[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownScheme, name)
+	}
+	return scheme, nil
+}
@@ -0,0 +1,131 @@
+package translit
+
+import (
+	"errors"
+	"flag"
+	"testing"
+)
+
+func TestCyrillicToLatinWithRomanizationSchemes(t *testing.T) {
+	tests := []struct {
+		scheme   string
+		input    string
+		expected string
+	}{
+		// ISO 9:1995 has a single Latin letter for every Cyrillic one
+		{"iso9", "Љубљана", "L̂ubl̂ana"},
+		{"iso9", "Њиш", "N̂iš"},
+		{"iso9", "Џеп", "D̂ep"},
+		{"iso9", "Југославија", "J̌ugoslaviǰa"},
+		{"iso9", "Ђорђе Ћосић", "Đorđe Ćosić"},
+		{"iso9", "Ѓевѓелија, Ќерка, Ѕвезда", "Ǵevǵeliǰa, Ḱerka, Ẑvezda"},
+		{"iso9", "Щукин", "Ŝukin"},
+		// BGN/PCGN uses the Serbian Latin alphabet
+		{"bgn", "Београд, Ниш, Чачак", "Beograd, Niš, Čačak"},
+		{"bgn", "Љубовија и Његош", "Ljubovija i Njegoš"},
+		{"bgn", "Ѓевѓелија", "Đevđelija"},
+		{"bgn", "Кичево и Ѕвечан", "Kičevo i Dzvečan"},
+		// ICAO Doc 9303 uses only the letters of the English alphabet
+		{"icao", "Ђорђе Чолић", "Dorde Cholic"},
+		{"icao", "Жарко Шћекић", "Zharko Shcekic"},
+		{"icao", "Џаја Хаџић", "Dzaja Khadzic"},
+		{"icao", "Љиљана Њежић", "Ljiljana Njezhic"},
+		{"icao", "Щукин, Горбачёв", "Shchukin, Gorbachev"},
+		{"icao", "ЧОЛИЋ ЖАРКО", "CHOLIC ZHARKO"},
+	}
+
+	for _, test := range tests {
+		output, err := CyrillicToLatinWithScheme(test.input, test.scheme)
+		if err != nil {
+			t.Fatalf("Шема %s: %v", test.scheme, err)
+		}
+		if output != test.expected {
+			t.Errorf("Шема %s: %q је пресловљено у %q, а очекује се %q", test.scheme, test.input, output, test.expected)
+		}
+	}
+}
+
+func TestCyrillicToLatinDigraphCase(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		// headlines
+		{"ЏАК И ЊИВА", "DŽAK I NJIVA"},
+		{"У ЊИВИ ЈЕ Џ", "U NJIVI JE DŽ"},
+		{"НАСЛОВ. Џ је слово.", "NASLOV. Dž je slovo."},
+		// acronyms
+		{"Џ.Б. је дошао", "DŽ.B. je došao"},
+		{"Њ.В. КРАЉ", "NJ.V. KRALJ"},
+		// hyphenated compounds
+		{"ЏЕП-Ђорђе", "DŽEP-Đorđe"},
+		{"Љиљана-ЉУБА", "Ljiljana-LJUBA"},
+		// title-cased names and initials
+		{"Љиљана Џ. Петровић", "Ljiljana Dž. Petrović"},
+		{"Џ. Р. Р. ТОЛКИН", "DŽ. R. R. TOLKIN"},
+		{"Џ", "Dž"},
+	}
+
+	for _, test := range tests {
+		if output := CyrillicToLatin(test.input); output != test.expected {
+			t.Errorf("%q је пресловљено у %q, а очекује се %q", test.input, output, test.expected)
+		}
+	}
+	if output := ToASCII("ЏАК И Ђ"); output != "DZAK I DJ" {
+		t.Errorf("%q је пресловљено у %q, а очекује се %q", "ЏАК И Ђ", output, "DZAK I DJ")
+	}
+}
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		word  string
+		rule  string
+		match string
+		split string
+	}{
+		{"kuća", "transliterated", "", ""},
+		{"alchajmer", "serbian_word_with_foreign_character_combinations", "alchajmer", ""},
+		{"Web", "foreign_character_combinations", "w", ""},
+		{"Adobe", "common_foreign_words", "adobe", ""},
+		{"and", "whole_foreign_words", "and", ""},
+		{"air-kondicioner", "foreign_prefix", "air", ""},
+		{"5km", "unit", "5km", ""},
+		{"injekcija", "digraph_exception", "injekc", "in\u200Cjekcija"},
+	}
+
+	for _, test := range tests {
+		explanations := Explain(test.word)
+		if len(explanations) != 1 {
+			t.Fatalf("%q: очекује се објашњење за једну реч, а има их %d", test.word, len(explanations))
+		}
+		explanation := explanations[0]
+		if explanation.Rule != test.rule || explanation.Match != test.match || explanation.Split != test.split {
+			t.Errorf("%q: правило %s (%q, %q), а очекује се %s (%q, %q)", test.word, explanation.Rule,
+				explanation.Match, explanation.Split, test.rule, test.match, test.split)
+		}
+		if explanation.Result != LatinToCyrillic(test.word) {
+			t.Errorf("%q: објашњење даје %q, а пресловљавање %q", test.word, explanation.Result, LatinToCyrillic(test.word))
+		}
+	}
+}
+
+func TestLatinToCyrillicWithRomanizationScheme(t *testing.T) {
+	if _, err := LatinToCyrillicWithScheme("Beograd", "iso9"); !errors.Is(err, ErrLatinOnlyScheme) {
+		t.Errorf("Шема iso9 не сме да се користи за пресловљавање у ћирилицу: %v", err)
+	}
+	if _, err := ExplainWithScheme("Beograd", "bgn"); !errors.Is(err, ErrLatinOnlyScheme) {
+		t.Errorf("Шема bgn не сме да се користи за објашњење пресловљавања у ћирилицу: %v", err)
+	}
+	if _, err := CyrillicToLatinWithScheme("Београд", "nepostojeca"); !errors.Is(err, ErrUnknownScheme) {
+		t.Errorf("Непозната шема мора да врати грешку: %v", err)
+	}
+}
+
+func TestNoFlags(t *testing.T) {
+	// the flags of the program must not clash with the flags of the programs which use the library
+	for _, name := range []string{"l2c", "i", "config", "report", "scheme"} {
+		if flag.Lookup(name) != nil {
+			t.Errorf("Библиотека је додала заставицу -%s", name)
+		}
+	}
+}